@@ -0,0 +1,202 @@
+package intset
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// An AdaptiveSet stores its members in a SparseSet while they are few,
+// and in a BitSet once they become dense, so that the right
+// representation need not be chosen up front. Once at least one in 64
+// of the values the set can hold are members, scanning a bitmap costs
+// no more than visiting the members one by one, and the bitmap uses
+// far less memory, so the set converts itself. If the set later
+// becomes sparse again, with fewer than one in 256 values as members,
+// it converts back.
+//
+// Each conversion happens at most once over the lifetime of the set, so
+// a set whose size hovers around a threshold never thrashes between
+// representations. A conversion takes O(n) time, where n == capacity,
+// and allocates the new representation.
+//
+// An AdaptiveSet supports Add, Remove, and Clear, with the time
+// complexity of whichever representation it is using.
+type AdaptiveSet struct {
+	sparse *SparseSet
+	bits   *BitSet
+
+	// Whether the set has ever converted to, or back from, a bitmap.
+	densified  bool
+	sparsified bool
+}
+
+// The densities, as a fraction 1/denominator of capacity, at which an
+// AdaptiveSet converts between representations.
+const (
+	adaptiveDenseDenominator  = 64
+	adaptiveSparseDenominator = 256
+)
+
+// Allocate a new AdaptiveSet.
+// The resulting set will be able to store the integers less than
+// capacity. It starts out as a SparseSet.
+func NewAdaptiveSet(capacity int) *AdaptiveSet {
+	return &AdaptiveSet{sparse: NewSparseSet(capacity)}
+}
+
+// Returns true if the set is currently stored as a bitmap.
+func (a *AdaptiveSet) IsBitmap() bool {
+	return a.bits != nil
+}
+
+// Converts the set to whichever representation suits its current
+// density, if it has not already made that conversion.
+func (a *AdaptiveSet) adapt() {
+	capacity, size := a.Capacity(), a.Size()
+	switch {
+	case a.bits == nil && !a.densified && size*adaptiveDenseDenominator >= capacity:
+		a.bits = NewBitSet(capacity)
+		for _, v := range a.sparse.Values() {
+			a.bits.Add(v)
+		}
+
+		a.sparse = nil
+		a.densified = true
+	case a.bits != nil && !a.sparsified && size*adaptiveSparseDenominator < capacity:
+		a.sparse = NewSparseSet(capacity)
+		for v := range a.bits.All() {
+			a.sparse.Add(v)
+		}
+
+		a.bits = nil
+		a.sparsified = true
+	}
+}
+
+// Returns true if value is a member of the set.
+func (a *AdaptiveSet) Contains(value int) bool {
+	if a.bits != nil {
+		return a.bits.Contains(value)
+	}
+
+	return a.sparse.Contains(value)
+}
+
+// Removes all elements from the set. The set keeps its current
+// representation.
+func (a *AdaptiveSet) Clear() {
+	if a.bits != nil {
+		a.bits.Clear()
+	} else {
+		a.sparse.Clear()
+	}
+}
+
+// Returns the size of the set.
+func (a *AdaptiveSet) Size() int {
+	if a.bits != nil {
+		return a.bits.Size()
+	}
+
+	return a.sparse.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (a *AdaptiveSet) Capacity() int {
+	if a.bits != nil {
+		return a.bits.Capacity()
+	}
+
+	return a.sparse.Capacity()
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (a *AdaptiveSet) Add(value int) error {
+	var err error
+	if a.bits != nil {
+		err = a.bits.Add(value)
+	} else {
+		err = a.sparse.Add(value)
+	}
+
+	if err == nil {
+		a.adapt()
+	}
+
+	return err
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (a *AdaptiveSet) Remove(item int) {
+	if a.bits != nil {
+		a.bits.Remove(item)
+	} else {
+		a.sparse.Remove(item)
+	}
+
+	a.adapt()
+}
+
+// Remove and return an arbitrary member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (a *AdaptiveSet) Pop() (int, error) {
+	var value int
+	var err error
+	if a.bits != nil {
+		value, err = a.bits.Pop()
+	} else {
+		value, err = a.sparse.Pop()
+	}
+
+	if err == nil {
+		a.adapt()
+	}
+
+	return value, err
+}
+
+// Returns a slice containing the members of the set.
+// This slice should not be modified. While the set is stored as a
+// bitmap, the slice is newly allocated on every call.
+func (a *AdaptiveSet) Values() []int {
+	if a.bits != nil {
+		return a.bits.Values()
+	}
+
+	return a.sparse.Values()
+}
+
+// Returns an iterator over the members of the set.
+// The member being visited may safely be removed during iteration,
+// even if doing so converts the set.
+func (a *AdaptiveSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if a.bits != nil {
+			for v := range a.bits.All() {
+				if !yield(v) {
+					return
+				}
+			}
+		} else {
+			for v := range a.sparse.All() {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// current representation.
+func (a *AdaptiveSet) MemoryUsage() int {
+	result := int(unsafe.Sizeof(*a))
+	if a.bits != nil {
+		return result + a.bits.MemoryUsage()
+	}
+
+	return result + a.sparse.MemoryUsage()
+}
@@ -0,0 +1,70 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestAdaptiveSetConverts(t *testing.T) {
+	set := NewAdaptiveSet(1024)
+	assert(t, !set.IsBitmap(), "set should start sparse")
+
+	for v := 0; v < 15; v++ {
+		set.Add(v)
+	}
+	assert(t, !set.IsBitmap(), "set should still be sparse")
+
+	set.Add(100)
+	assert(t, set.IsBitmap(), "set should have converted to a bitmap")
+	assert(t, set.Size() == 16, "set size should be 16")
+	assert(t, set.Contains(100) && set.Contains(0) && !set.Contains(15), "members should survive conversion")
+
+	for v := 0; v < 12; v++ {
+		set.Remove(v)
+	}
+	assert(t, set.IsBitmap(), "set should still be a bitmap")
+
+	set.Remove(12)
+	assert(t, !set.IsBitmap(), "set should have converted back")
+
+	values := set.Values()
+	slices.Sort(values)
+	assert(t, slices.Equal(values, []int{13, 14, 100}), "values should be [13 14 100], got %v", values)
+
+	for v := 0; v < 1024; v++ {
+		set.Add(v)
+	}
+	assert(t, !set.IsBitmap(), "set should convert only once in each direction")
+	assert(t, set.Size() == 1024, "set size should be 1024")
+}
+
+func TestAdaptiveSetOperations(t *testing.T) {
+	set := NewAdaptiveSet(64)
+
+	err := set.Add(64)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assert(t, set.Capacity() == 64, "set capacity should be 64")
+
+	set.Add(3)
+	set.Add(5)
+	assert(t, set.IsBitmap(), "set should be a bitmap")
+
+	seen := 0
+	for v := range set.All() {
+		set.Remove(v)
+		seen += v
+	}
+	assert(t, seen == 8 && set.Size() == 0, "All should visit every member")
+
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(7)
+	v, err := set.Pop()
+	assert(t, err == nil && v == 7, "pop should return 7")
+
+	set.Add(9)
+	set.Clear()
+	assert(t, set.Size() == 0, "set should be empty")
+}
@@ -0,0 +1,195 @@
+package intset
+
+import (
+	"iter"
+)
+
+// Returns the sum of the members of s, or 0 if it is empty. The sum
+// wraps around if it overflows an int.
+// This takes O(k) time, where k is the size of s.
+func Sum(s IntSet) int {
+	sum := 0
+	for _, v := range s.Values() {
+		sum += v
+	}
+
+	return sum
+}
+
+// Returns the arithmetic mean of the members of s.
+// This takes O(k) time, where k is the size of s.
+// If s is empty, the result will be 0 and error will be ErrEmptySet.
+func Mean(s IntSet) (float64, error) {
+	values := s.Values()
+	if len(values) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	mean := 0.0
+	for i, v := range values {
+		mean += (float64(v) - mean) / float64(i+1)
+	}
+
+	return mean, nil
+}
+
+// Returns the median of the members of s: the middle member if s has an
+// odd number of them, and the mean of the two middle members otherwise.
+// The members are copied and partially sorted with quickselect, so this
+// takes expected O(k) time, where k is the size of s, and allocates.
+// If s is empty, the result will be 0 and error will be ErrEmptySet.
+func Median(s IntSet) (float64, error) {
+	values := append([]int(nil), s.Values()...)
+	n := len(values)
+	if n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	upper := nthSmallest(values, n/2)
+	if n%2 == 1 {
+		return float64(upper), nil
+	}
+
+	// After selection every value before n/2 is no greater than upper, so
+	// the lower middle member is the largest of them.
+	lower := values[0]
+	for _, v := range values[1 : n/2] {
+		lower = max(lower, v)
+	}
+
+	return float64(lower)/2 + float64(upper)/2, nil
+}
+
+// Rearranges values so that the one at index k is the one that would be
+// there if they were sorted, with no greater value before it and no
+// smaller value after it, and returns it.
+func nthSmallest(values []int, k int) int {
+	lo, hi := 0, len(values)-1
+	for lo < hi {
+		pivot := values[lo+(hi-lo)/2]
+		i, j := lo, hi
+		for i <= j {
+			for values[i] < pivot {
+				i++
+			}
+
+			for values[j] > pivot {
+				j--
+			}
+
+			if i <= j {
+				values[i], values[j] = values[j], values[i]
+				i++
+				j--
+			}
+		}
+
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return values[k]
+		}
+	}
+
+	return values[k]
+}
+
+// A SummedSet is a SparseSet that keeps a running total of its members,
+// so that Sum and Mean take O(1) time. The other operations take the
+// same time as they do for a SparseSet.
+type SummedSet struct {
+	members *SparseSet
+	sum     int
+}
+
+// Allocate a new, empty SummedSet able to store the integers less than
+// capacity.
+func NewSummedSet(capacity int) *SummedSet {
+	return &SummedSet{members: NewSparseSet(capacity)}
+}
+
+// Returns true if value is a member of the set.
+func (s *SummedSet) Contains(value int) bool {
+	return s.members.Contains(value)
+}
+
+// Removes all elements from the set.
+func (s *SummedSet) Clear() {
+	s.members.Clear()
+	s.sum = 0
+}
+
+// Returns the size of the set.
+func (s *SummedSet) Size() int {
+	return s.members.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *SummedSet) Capacity() int {
+	return s.members.Capacity()
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (s *SummedSet) Add(value int) error {
+	added, err := s.members.AddReported(value)
+	if added {
+		s.sum += value
+	}
+
+	return err
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *SummedSet) Remove(item int) {
+	if s.members.Contains(item) {
+		s.members.Remove(item)
+		s.sum -= item
+	}
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SummedSet) Pop() (int, error) {
+	value, err := s.members.Pop()
+	if err == nil {
+		s.sum -= value
+	}
+
+	return value, err
+}
+
+// Returns the sum of the members of the set, or 0 if it is empty, in
+// O(1) time. The sum wraps around if it overflows an int.
+func (s *SummedSet) Sum() int {
+	return s.sum
+}
+
+// Returns the arithmetic mean of the members of the set, in O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SummedSet) Mean() (float64, error) {
+	if s.members.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return float64(s.sum) / float64(s.members.n), nil
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (s *SummedSet) Values() []int {
+	return s.members.Values()
+}
+
+// Returns an iterator over the members of the set.
+// As with SparseSet, the member being visited may safely be removed
+// during iteration.
+func (s *SummedSet) All() iter.Seq[int] {
+	return s.members.All()
+}
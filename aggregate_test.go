@@ -0,0 +1,75 @@
+package intset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestAggregates(t *testing.T) {
+	set := growSetOf(20, 3, 1, 4, 15, 9)
+	assert(t, Sum(set) == 32, "sum should be 32, is %v", Sum(set))
+
+	mean, err := Mean(set)
+	assert(t, err == nil && mean == 6.4, "mean should be 6.4, is %v", mean)
+
+	median, err := Median(set)
+	assert(t, err == nil && median == 4, "median should be 4, is %v", median)
+
+	set.Add(6)
+	median, _ = Median(set)
+	assert(t, median == 5, "median should be 5, is %v", median)
+	assert(t, set.Contains(15) && set.Size() == 6, "Median should not modify the set")
+
+	empty := NewGrowSet(5)
+	_, err = Mean(empty)
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, err = Median(empty)
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, Sum(empty) == 0, "sum of empty set should be 0")
+}
+
+func TestMedianRandom(t *testing.T) {
+	for trial := 0; trial < 100; trial++ {
+		set := NewSparseSetRange(-50, 50)
+		for i := rand.Intn(60) + 1; i > 0; i-- {
+			set.Add(rand.Intn(100) - 50)
+		}
+
+		sorted := slices.Sorted(set.All())
+		n := len(sorted)
+		want := float64(sorted[n/2])
+		if n%2 == 0 {
+			want = float64(sorted[n/2-1]+sorted[n/2]) / 2
+		}
+
+		median, err := Median(set)
+		assert(t, err == nil && median == want, "median of %v should be %v, is %v", sorted, want, median)
+	}
+}
+
+func TestSummedSet(t *testing.T) {
+	s := NewSummedSet(100)
+	_, err := s.Mean()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	s.Add(10)
+	s.Add(20)
+	s.Add(20)
+	s.Add(60)
+	assert(t, s.Sum() == 90, "sum should be 90, is %v", s.Sum())
+
+	mean, _ := s.Mean()
+	assert(t, mean == 30, "mean should be 30, is %v", mean)
+
+	s.Remove(20)
+	s.Remove(21)
+	assert(t, s.Sum() == 70, "sum should be 70, is %v", s.Sum())
+
+	value, _ := s.Pop()
+	assert(t, s.Sum() == 70-value, "pop should update the sum")
+	assert(t, s.Sum() == Sum(s), "running sum should match Sum")
+
+	s.Clear()
+	assert(t, s.Sum() == 0 && s.Size() == 0, "clear should reset the sum")
+}
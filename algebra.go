@@ -0,0 +1,441 @@
+package intset
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// Returns a new GrowSet containing every value that is a member of
+// either g or other. The new set is able to store any value that
+// either of its inputs could store.
+// This takes O(|g| + |other|) time, plus the cost of construction.
+func (g *GrowSet) Union(other *GrowSet) *GrowSet {
+	gMin, gMax := (*set)(g).bounds()
+	otherMin, otherMax := (*set)(other).bounds()
+
+	result := NewGrowSetRange(min(gMin, otherMin), max(gMax, otherMax))
+	for _, v := range g.Values() {
+		result.Add(v)
+	}
+
+	for _, v := range other.Values() {
+		result.Add(v)
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing the values that are members of
+// both g and other. The new set is able to store the values that both
+// of its inputs could store.
+// This takes O(min(|g|, |other|)) time, plus the cost of construction.
+func (g *GrowSet) Intersect(other *GrowSet) *GrowSet {
+	gMin, gMax := (*set)(g).bounds()
+	otherMin, otherMax := (*set)(other).bounds()
+	lo, hi := max(gMin, otherMin), min(gMax, otherMax)
+
+	small, large := g, other
+	if large.n < small.n {
+		small, large = large, small
+	}
+
+	result := NewGrowSetRange(lo, max(lo, hi))
+	for _, v := range small.Values() {
+		if large.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing the values that are members of g
+// but not of other. The new set is able to store the same values as g.
+// This takes O(|g|) time, plus the cost of construction.
+func (g *GrowSet) Difference(other *GrowSet) *GrowSet {
+	result := NewGrowSetRange((*set)(g).bounds())
+	for _, v := range g.Values() {
+		if !other.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing the values that are members of
+// exactly one of g and other. The new set is able to store any value
+// that either of its inputs could store.
+// This takes O(|g| + |other|) time, plus the cost of construction.
+func (g *GrowSet) SymmetricDifference(other *GrowSet) *GrowSet {
+	gMin, gMax := (*set)(g).bounds()
+	otherMin, otherMax := (*set)(other).bounds()
+
+	result := NewGrowSetRange(min(gMin, otherMin), max(gMax, otherMax))
+	for _, v := range g.Values() {
+		if !other.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	for _, v := range other.Values() {
+		if !g.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Adds every member of other to g, without allocating.
+// Members of other too small or too large to be stored in g are skipped,
+// and a *RangeError for the last of them is returned; otherwise the
+// result is nil.
+// This takes O(|other|) time.
+func (g *GrowSet) UnionWith(other *GrowSet) error {
+	var err error
+	for _, v := range other.Values() {
+		if addErr := g.Add(v); addErr != nil {
+			err = addErr
+		}
+	}
+
+	return err
+}
+
+// Removes every member of g that is not also a member of other,
+// without allocating.
+// This takes O(|g|) time.
+func (g *GrowSet) IntersectWith(other *GrowSet) {
+	g.retain(other.Contains)
+}
+
+// Removes every member of other from g, without allocating.
+// This takes O(|g|) time.
+func (g *GrowSet) DifferenceWith(other *GrowSet) {
+	g.retain(func(v int) bool { return !other.Contains(v) })
+}
+
+// Replaces g with the values that are members of exactly one of g and
+// other, without allocating. The members of other that are not members
+// of g are added after the common members are removed.
+// Members of other too small or too large to be stored in g are skipped,
+// and a *RangeError for the last of them is returned; otherwise the
+// result is nil.
+// This takes O(|g| + |other|) time.
+func (g *GrowSet) SymmetricDifferenceWith(other *GrowSet) error {
+	// The members of other that are not yet members of g are appended to
+	// the dense array, so the common members are those before them that
+	// other contains.
+	n := g.n
+	var err error
+	for _, v := range other.Values() {
+		if addErr := g.Add(v); addErr != nil {
+			err = addErr
+		}
+	}
+
+	i := 0
+	g.retain(func(v int) bool {
+		keep := i >= n || !other.Contains(v)
+		i++
+		return keep
+	})
+
+	return err
+}
+
+// Compacts the dense array in place, keeping only the values for which
+// keep returns true.
+func (g *GrowSet) retain(keep func(int) bool) {
+	(*set)(g).own()
+	n := 0
+	for i := 0; i < g.n; i++ {
+		value := g.dense[i]
+		if keep(value) {
+			g.dense[n] = value
+			g.sparse[value-g.offset] = n
+			n++
+		}
+	}
+
+	g.mods += uint(g.n - n)
+	g.n = n
+	g.stale = true
+}
+
+// Returns true if every value in values satisfies contains.
+func allContained(values []int, contains func(int) bool) bool {
+	for _, v := range values {
+		if !contains(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if every member of g is also a member of other.
+// This takes O(|g|) time.
+func (g *GrowSet) IsSubsetOf(other *GrowSet) bool {
+	return g.n <= other.n && allContained(g.Values(), other.Contains)
+}
+
+// Returns true if every member of other is also a member of g.
+// This takes O(|other|) time.
+func (g *GrowSet) IsSupersetOf(other *GrowSet) bool {
+	return other.IsSubsetOf(g)
+}
+
+// Returns true if g and other have exactly the same members.
+// This takes O(|g|) time.
+func (g *GrowSet) Equal(other *GrowSet) bool {
+	return g.n == other.n && allContained(g.Values(), other.Contains)
+}
+
+// Returns true if every member of s is also a member of other.
+// This takes O(|s|) time.
+func (s *ShrinkSet) IsSubsetOf(other *ShrinkSet) bool {
+	return s.n <= other.n && allContained(s.Values(), other.Contains)
+}
+
+// Returns true if every member of other is also a member of s.
+// This takes O(|other|) time.
+func (s *ShrinkSet) IsSupersetOf(other *ShrinkSet) bool {
+	return other.IsSubsetOf(s)
+}
+
+// Returns true if s and other have exactly the same members.
+// This takes O(|s|) time.
+func (s *ShrinkSet) Equal(other *ShrinkSet) bool {
+	return s.n == other.n && allContained(s.Values(), other.Contains)
+}
+
+// Returns true if any value in values satisfies contains, stopping at
+// the first one that does.
+func anyContained(values []int, contains func(int) bool) bool {
+	for _, v := range values {
+		if contains(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if g and other have at least one member in common.
+// This takes O(min(|g|, |other|)) time, and returns as soon as a
+// common member is found.
+func (g *GrowSet) Intersects(other *GrowSet) bool {
+	if other.n < g.n {
+		return anyContained(other.Values(), g.Contains)
+	}
+
+	return anyContained(g.Values(), other.Contains)
+}
+
+// Returns true if g and other have no members in common.
+func (g *GrowSet) IsDisjoint(other *GrowSet) bool {
+	return !g.Intersects(other)
+}
+
+// Returns true if s and other have at least one member in common.
+// This takes O(min(|s|, |other|)) time, and returns as soon as a
+// common member is found.
+func (s *ShrinkSet) Intersects(other *ShrinkSet) bool {
+	if other.n < s.n {
+		return anyContained(other.Values(), s.Contains)
+	}
+
+	return anyContained(s.Values(), other.Contains)
+}
+
+// Returns true if s and other have no members in common.
+func (s *ShrinkSet) IsDisjoint(other *ShrinkSet) bool {
+	return !s.Intersects(other)
+}
+
+// The smallest and largest of a collection of values, for sizing a set
+// to hold them.
+type extent struct {
+	lo, hi int
+	found  bool
+}
+
+// Extends the extent to include value.
+func (e *extent) include(value int) {
+	if !e.found {
+		e.lo, e.hi, e.found = value, value, true
+		return
+	}
+
+	e.lo, e.hi = min(e.lo, value), max(e.hi, value)
+}
+
+// Returns a new, empty GrowSet able to hold just the values from the
+// smallest included to the largest, which may be math.MaxInt.
+// Panics if there are more of them than a set can hold.
+func (e *extent) newGrowSet() *GrowSet {
+	if !e.found {
+		return NewGrowSetRange(0, 0)
+	}
+
+	// Computed in uint so that it cannot overflow, however far apart lo
+	// and hi are; it wraps to zero only for the span of every int.
+	capacity := uint(e.hi) - uint(e.lo) + 1
+	if capacity == 0 || capacity > math.MaxInt {
+		panic(fmt.Sprintf("intset: values from %d to %d are too many for a set", e.lo, e.hi))
+	}
+
+	// Built from a zero-based set rather than with NewGrowSetRange, whose
+	// maximum would overflow if hi is math.MaxInt.
+	result := NewGrowSet(int(capacity))
+	result.offset = e.lo
+	return result
+}
+
+// Returns a new, empty GrowSet just large enough to hold every value in
+// each of values, from the smallest to the largest.
+func newGrowSetFor(values ...[]int) *GrowSet {
+	var e extent
+	for _, vs := range values {
+		for _, v := range vs {
+			e.include(v)
+		}
+	}
+
+	return e.newGrowSet()
+}
+
+// Returns a new GrowSet with the same members as s. The new set is just
+// large enough to hold the members of s, from the smallest to the
+// largest.
+func Copy(s IntSet) *GrowSet {
+	values := s.Values()
+	result := newGrowSetFor(values)
+	for _, v := range values {
+		result.Add(v)
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing every value that is a member of
+// either a or b.
+func Union(a, b IntSet) *GrowSet {
+	av, bv := a.Values(), b.Values()
+	result := newGrowSetFor(av, bv)
+	for _, v := range av {
+		result.Add(v)
+	}
+
+	for _, v := range bv {
+		result.Add(v)
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing the values that are members of
+// both a and b.
+func Intersect(a, b IntSet) *GrowSet {
+	if b.Size() < a.Size() {
+		a, b = b, a
+	}
+
+	values := a.Values()
+	result := newGrowSetFor(values)
+	for _, v := range values {
+		if b.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Returns an iterator over the values that are members of both a and b,
+// which computes the intersection lazily rather than building a third
+// set. Each member of the smaller set is visited, in the order its All
+// method visits them if it has one, and yielded if the larger set
+// contains it, so iteration takes O(min(|a|, |b|)) time. If the smaller
+// set has an All method, iteration allocates nothing for each member.
+func IntersectSeq(a, b IntSet) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		small, large := a, b
+		if large.Size() < small.Size() {
+			small, large = large, small
+		}
+
+		for v := range members(small) {
+			if large.Contains(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns an iterator over the members of s, using its All method if it
+// has one.
+func members(s IntSet) iter.Seq[int] {
+	if s, ok := s.(interface{ All() iter.Seq[int] }); ok {
+		return s.All()
+	}
+
+	return slices.Values(s.Values())
+}
+
+// Returns a new GrowSet containing the values that are members of a
+// but not of b.
+func Difference(a, b IntSet) *GrowSet {
+	values := a.Values()
+	result := newGrowSetFor(values)
+	for _, v := range values {
+		if !b.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Returns a new GrowSet containing the values that are members of
+// exactly one of a and b.
+func SymmetricDifference(a, b IntSet) *GrowSet {
+	av, bv := a.Values(), b.Values()
+	result := newGrowSetFor(av, bv)
+	for _, v := range av {
+		if !b.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	for _, v := range bv {
+		if !a.Contains(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// Returns true if every member of a is also a member of b.
+func IsSubset(a, b IntSet) bool {
+	return a.Size() <= b.Size() && allContained(a.Values(), b.Contains)
+}
+
+// Returns true if a and b have exactly the same members.
+func Equal(a, b IntSet) bool {
+	return a.Size() == b.Size() && allContained(a.Values(), b.Contains)
+}
+
+// Returns true if a and b have at least one member in common.
+func Intersects(a, b IntSet) bool {
+	if b.Size() < a.Size() {
+		a, b = b, a
+	}
+
+	return anyContained(a.Values(), b.Contains)
+}
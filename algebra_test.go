@@ -0,0 +1,282 @@
+package intset
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func growSetOf(capacity int, values ...int) *GrowSet {
+	set := NewGrowSet(capacity)
+	for _, v := range values {
+		set.Add(v)
+	}
+
+	return set
+}
+
+func assertMembers(t *testing.T, contains func(int) bool, size int, capacity int, members ...int) {
+	assert(t, size == len(members), "set size should be %v, is %v", len(members), size)
+
+	expected := make(map[int]bool)
+	for _, v := range members {
+		expected[v] = true
+	}
+
+	for i := 0; i < capacity; i++ {
+		assert(t, contains(i) == expected[i], "membership of %v should be %v", i, expected[i])
+	}
+}
+
+func TestGrowSetUnion(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4)
+	b := growSetOf(10, 1, 2, 8)
+
+	u := a.Union(b)
+	assertMembers(t, u.Contains, u.Size(), 10, 0, 1, 2, 4, 8)
+
+	u = b.Union(a)
+	assertMembers(t, u.Contains, u.Size(), 10, 0, 1, 2, 4, 8)
+}
+
+func TestGrowSetIntersect(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4, 5)
+	b := growSetOf(10, 1, 2, 5, 8)
+
+	i := a.Intersect(b)
+	assertMembers(t, i.Contains, i.Size(), 10, 2, 5)
+
+	i = b.Intersect(a)
+	assertMembers(t, i.Contains, i.Size(), 10, 2, 5)
+
+	i = a.Intersect(NewGrowSet(3))
+	assertMembers(t, i.Contains, i.Size(), 10)
+}
+
+func TestGrowSetDifference(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4, 5)
+	b := growSetOf(10, 1, 2, 5, 8)
+
+	d := a.Difference(b)
+	assertMembers(t, d.Contains, d.Size(), 10, 0, 4)
+
+	d = b.Difference(a)
+	assertMembers(t, d.Contains, d.Size(), 10, 1, 8)
+}
+
+func TestGrowSetUnionWith(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4)
+	b := growSetOf(6, 1, 2, 5)
+
+	err := a.UnionWith(b)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, a.Contains, a.Size(), 6, 0, 1, 2, 4, 5)
+
+	c := growSetOf(10, 3, 8)
+	err = a.UnionWith(c)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, a.Contains, a.Size(), 10, 0, 1, 2, 3, 4, 5)
+}
+
+func TestGrowSetIntersectWith(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4, 5)
+	b := growSetOf(10, 1, 2, 5, 8)
+
+	a.IntersectWith(b)
+	assertMembers(t, a.Contains, a.Size(), 10, 2, 5)
+
+	a.Add(3)
+	assertMembers(t, a.Contains, a.Size(), 10, 2, 3, 5)
+}
+
+func TestGrowSetDifferenceWith(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4, 5)
+	b := growSetOf(10, 1, 2, 5, 8)
+
+	a.DifferenceWith(b)
+	assertMembers(t, a.Contains, a.Size(), 10, 0, 4)
+
+	a.DifferenceWith(a)
+	assertMembers(t, a.Contains, a.Size(), 10)
+}
+
+func TestGrowSetSymmetricDifference(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4, 5)
+	b := growSetOf(10, 1, 2, 5, 8)
+
+	d := a.SymmetricDifference(b)
+	assertMembers(t, d.Contains, d.Size(), 10, 0, 1, 4, 8)
+
+	d = SymmetricDifference(b, a)
+	assertMembers(t, d.Contains, d.Size(), 10, 0, 1, 4, 8)
+
+	err := b.SymmetricDifferenceWith(a)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, b.Contains, b.Size(), 10, 0, 1, 4, 8)
+
+	err = a.SymmetricDifferenceWith(growSetOf(10, 0, 3, 9))
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, a.Contains, a.Size(), 6, 2, 3, 4, 5)
+
+	a.SymmetricDifferenceWith(a)
+	assertMembers(t, a.Contains, a.Size(), 6)
+}
+
+func TestIntersectSeq(t *testing.T) {
+	a := growSetOf(10, 0, 2, 4, 5, 8)
+	b := NewBitSet(10)
+	b.Add(2)
+	b.Add(5)
+	b.Add(9)
+
+	var common []int
+	for v := range IntersectSeq(a, b) {
+		common = append(common, v)
+	}
+
+	slices.Sort(common)
+	assert(t, slices.Equal(common, []int{2, 5}), "intersection should be [2 5], is %v", common)
+
+	for v := range IntersectSeq(b, a) {
+		assert(t, v == 2 || v == 5, "%v should not be in the intersection", v)
+		break
+	}
+
+	large := NewSparseSet(1000)
+	for v := 0; v < 1000; v += 3 {
+		large.Add(v)
+	}
+
+	small := growSetOf(20, 3, 4, 15)
+	allocs := testing.AllocsPerRun(10, func() {
+		for range IntersectSeq(large, small) {
+		}
+	})
+	fewer := testing.AllocsPerRun(10, func() {
+		for range IntersectSeq(large, growSetOf(20, 3)) {
+		}
+	}) - 2
+	assert(t, allocs <= fewer+2, "iteration should not allocate per member, allocated %v", allocs)
+
+	count := 0
+	for range IntersectSeq(large, small) {
+		count++
+	}
+
+	assert(t, count == 2, "intersection should have 2 members, has %v", count)
+}
+
+func TestGrowSetSubsetSupersetEqual(t *testing.T) {
+	a := growSetOf(6, 2, 4)
+	b := growSetOf(10, 1, 2, 4, 8)
+	c := growSetOf(10, 4, 2)
+
+	assert(t, a.IsSubsetOf(b), "a should be a subset of b")
+	assert(t, !b.IsSubsetOf(a), "b should not be a subset of a")
+	assert(t, b.IsSupersetOf(a), "b should be a superset of a")
+	assert(t, !a.IsSupersetOf(b), "a should not be a superset of b")
+	assert(t, a.Equal(c) && c.Equal(a), "a and c should be equal")
+	assert(t, !a.Equal(b), "a and b should not be equal")
+	assert(t, NewGrowSet(0).IsSubsetOf(a), "empty set should be a subset of a")
+}
+
+func TestShrinkSetSubsetSupersetEqual(t *testing.T) {
+	a := NewShrinkSet(6)
+	a.Remove(0)
+	a.Remove(5)
+	b := NewShrinkSet(10)
+	c := NewShrinkSet(5)
+	c.Remove(0)
+
+	assert(t, a.IsSubsetOf(b), "a should be a subset of b")
+	assert(t, !b.IsSubsetOf(a), "b should not be a subset of a")
+	assert(t, b.IsSupersetOf(a), "b should be a superset of a")
+	assert(t, a.Equal(c) && c.Equal(a), "a and c should be equal")
+	assert(t, !a.Equal(b), "a and b should not be equal")
+
+	b.Remove(3)
+	assert(t, !a.IsSubsetOf(b), "a should not be a subset of b")
+}
+
+func TestGrowSetIntersects(t *testing.T) {
+	a := growSetOf(6, 0, 2, 4)
+	b := growSetOf(10, 1, 3, 8)
+	c := growSetOf(10, 1, 4)
+
+	assert(t, !a.Intersects(b) && a.IsDisjoint(b), "a and b should be disjoint")
+	assert(t, a.Intersects(c) && c.Intersects(a), "a and c should intersect")
+	assert(t, !a.IsDisjoint(c), "a and c should not be disjoint")
+	assert(t, NewGrowSet(6).IsDisjoint(a), "empty set should be disjoint from a")
+}
+
+func TestShrinkSetIntersects(t *testing.T) {
+	a := NewShrinkSet(4)
+	b := NewShrinkSet(6)
+	for i := 0; i < 4; i++ {
+		b.Remove(i)
+	}
+
+	assert(t, !a.Intersects(b) && a.IsDisjoint(b), "a and b should be disjoint")
+
+	b.Refill()
+	b.Remove(0)
+	assert(t, a.Intersects(b) && b.Intersects(a), "a and b should intersect")
+}
+
+func TestIntSetFunctions(t *testing.T) {
+	g := growSetOf(10, 1, 2, 8)
+	s := NewShrinkSet(4)
+	s.Remove(0)
+
+	c := Copy(s)
+	assertMembers(t, c.Contains, c.Size(), 10, 1, 2, 3)
+	assert(t, Equal(c, s) && Equal(s, c), "copy should equal original")
+
+	u := Union(g, s)
+	assertMembers(t, u.Contains, u.Size(), 10, 1, 2, 3, 8)
+
+	i := Intersect(g, s)
+	assertMembers(t, i.Contains, i.Size(), 10, 1, 2)
+
+	d := Difference(g, s)
+	assertMembers(t, d.Contains, d.Size(), 10, 8)
+
+	assert(t, IsSubset(i, g) && IsSubset(i, s), "intersection should be a subset of both")
+	assert(t, !IsSubset(g, s), "g should not be a subset of s")
+	assert(t, Intersects(g, s), "g and s should intersect")
+	assert(t, !Intersects(d, s), "difference should not intersect s")
+	assert(t, !Equal(g, s), "g and s should not be equal")
+}
+
+func TestCopySizing(t *testing.T) {
+	// Sets far from zero are copied without allocating the values below
+	// them.
+	far := NewGrowSetRange(1<<30, 1<<30+10)
+	far.Add(1<<30 + 3)
+	far.Add(1<<30 + 7)
+	copied := Copy(far)
+	assert(t, Equal(copied, far), "copy should have the same members")
+	assert(t, copied.Capacity() == 5, "copy should hold just its members' range, holds %v", copied.Capacity())
+
+	extremes := NewIntervalSet()
+	extremes.Add(math.MaxInt)
+	extremes.Add(math.MaxInt - 2)
+	copied = Copy(extremes)
+	assert(t, copied.Size() == 2 && copied.Contains(math.MaxInt) && copied.Contains(math.MaxInt-2), "copy should hold MaxInt")
+
+	negative := NewIntervalSet()
+	negative.Add(math.MinInt)
+	other := NewIntervalSet()
+	other.Add(math.MinInt + 4)
+	union := Union(negative, other)
+	assert(t, union.Size() == 2 && union.Contains(math.MinInt) && union.Contains(math.MinInt+4), "union should hold MinInt and MinInt+4")
+	assert(t, union.Capacity() == 5, "union should hold just its members' range, holds %v", union.Capacity())
+
+	assert(t, Copy(NewIntervalSet()).Size() == 0, "copy of an empty set should be empty")
+
+	everything := NewIntervalSet()
+	everything.Add(math.MinInt)
+	everything.Add(math.MaxInt)
+	assert(t, panicMessage(func() { Copy(everything) }) != "", "copying members too far apart should panic")
+}
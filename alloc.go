@@ -0,0 +1,53 @@
+package intset
+
+import (
+	"fmt"
+)
+
+// An Allocator returns a slice of length n for use as one of the arrays
+// of a set, allowing storage to come from an arena, cgo memory, or a
+// custom pool rather than the Go heap. The slice need not be cleared,
+// and must not be used by anything else for the lifetime of the set.
+type Allocator func(n int) []int
+
+// Returns two arrays of length capacity obtained from alloc.
+func allocate(alloc Allocator, capacity int) ([]int, []int) {
+	validateCapacity(capacity)
+	sparse, dense := alloc(capacity), alloc(capacity)
+	if len(sparse) != capacity || len(dense) != capacity {
+		panic(fmt.Sprintf("intset: allocator returned %d and %d entries, want %d", len(sparse), len(dense), capacity))
+	}
+
+	return sparse, dense
+}
+
+// Create a new, empty GrowSet able to store the integers less than
+// capacity, with its arrays obtained from alloc. Only construction uses
+// alloc; storage allocated later, such as by Grow, Clone, or Reset,
+// comes from the Go heap.
+// It panics if capacity is negative or alloc returns a slice of the
+// wrong length.
+func NewGrowSetWithAllocator(capacity int, alloc Allocator) *GrowSet {
+	return NewGrowSetWithBuffers(allocate(alloc, capacity))
+}
+
+// Create a new ShrinkSet storing the numbers up to, but not including,
+// capacity, with its arrays obtained from alloc. The arrays are cleared
+// so that the set can be initialized lazily, which takes O(n) time,
+// where n == capacity. Only construction uses alloc; storage allocated
+// later, such as by Grow, Clone, or Reset, comes from the Go heap.
+// It panics if capacity is negative or alloc returns a slice of the
+// wrong length.
+func NewShrinkSetWithAllocator(capacity int, alloc Allocator) *ShrinkSet {
+	return NewShrinkSetWithBuffers(allocate(alloc, capacity))
+}
+
+// Create a new, empty SparseSet able to store the integers less than
+// capacity, with its arrays obtained from alloc. Only construction uses
+// alloc; storage allocated later, such as by Grow, Clone, or Reset,
+// comes from the Go heap.
+// It panics if capacity is negative or alloc returns a slice of the
+// wrong length.
+func NewSparseSetWithAllocator(capacity int, alloc Allocator) *SparseSet {
+	return NewSparseSetWithBuffers(allocate(alloc, capacity))
+}
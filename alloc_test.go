@@ -0,0 +1,44 @@
+package intset
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithAllocator(t *testing.T) {
+	arena := make([]int, 64)
+	for i := range arena {
+		arena[i] = -1
+	}
+
+	calls := 0
+	alloc := func(n int) []int {
+		calls++
+		result := arena[:n:n]
+		arena = arena[n:]
+		return result
+	}
+
+	grow := NewGrowSetWithAllocator(10, alloc)
+	grow.AddAll(1, 9)
+	assertMembers(t, grow.Contains, grow.Size(), 10, 1, 9)
+
+	shrink := NewShrinkSetWithAllocator(10, alloc)
+	shrink.Remove(4)
+	assert(t, shrink.Size() == 9 && !shrink.Contains(4), "shrink set should have 4 removed")
+
+	sparse := NewSparseSetWithAllocator(5, alloc)
+	sparse.AddAll(0, 4)
+	sparse.Remove(0)
+	assertMembers(t, sparse.Contains, sparse.Size(), 5, 4)
+
+	assert(t, calls == 6, "allocator should be called 6 times, was called %v", calls)
+	assert(t, len(arena) == 14, "arena should have 14 entries left, has %v", len(arena))
+
+	defer func() {
+		message, _ := recover().(string)
+		assert(t, strings.Contains(message, "allocator returned"), "short allocation should panic, got %q", message)
+	}()
+
+	NewGrowSetWithAllocator(4, func(n int) []int { return make([]int, n-1) })
+}
@@ -0,0 +1,114 @@
+package intset
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// An AtomicGrowSet is a GrowSet that is safe for concurrent use and is
+// optimized for workloads that mostly read.
+// Contains and Size are wait-free: they never take a lock and never
+// block on a writer. Writers (Add, Clear, and Pop) are serialized by a
+// mutex.
+//
+// A writer publishes a new member by first filling in the dense and
+// sparse entries and only then incrementing the size, so a reader that
+// observes the new size is guaranteed to observe the entries as well.
+type AtomicGrowSet struct {
+	mutex  sync.Mutex
+	n      int64
+	sparse []int64
+	dense  []int64
+}
+
+// Allocate a new AtomicGrowSet.
+// The resulting set will be able to store the integers less than
+// capacity.
+func NewAtomicGrowSet(capacity int) *AtomicGrowSet {
+	validateCapacity(capacity)
+	return &AtomicGrowSet{
+		sparse: make([]int64, capacity, capacity),
+		dense:  make([]int64, capacity, capacity),
+	}
+}
+
+// Returns true if value is a member of the set. This never blocks.
+func (a *AtomicGrowSet) Contains(value int) bool {
+	if value < 0 || value >= len(a.sparse) {
+		return false
+	}
+
+	n := atomic.LoadInt64(&a.n)
+	index := atomic.LoadInt64(&a.sparse[value])
+	return index >= 0 && index < n && atomic.LoadInt64(&a.dense[index]) == int64(value)
+}
+
+// Returns the size of the set. This never blocks.
+func (a *AtomicGrowSet) Size() int {
+	return int(atomic.LoadInt64(&a.n))
+}
+
+// Returns the number of distinct values the set is able to store.
+func (a *AtomicGrowSet) Capacity() int {
+	return len(a.sparse)
+}
+
+// Removes all elements from the set.
+func (a *AtomicGrowSet) Clear() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	atomic.StoreInt64(&a.n, 0)
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (a *AtomicGrowSet) Add(value int) error {
+	if value >= len(a.sparse) || value < 0 {
+		return outOfRange(value, 0, len(a.sparse))
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.Contains(value) {
+		n := atomic.LoadInt64(&a.n)
+		atomic.StoreInt64(&a.dense[n], int64(value))
+		atomic.StoreInt64(&a.sparse[value], n)
+		atomic.StoreInt64(&a.n, n+1)
+	}
+
+	return nil
+}
+
+// Remove and return the most recently added member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (a *AtomicGrowSet) Pop() (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	n := atomic.LoadInt64(&a.n)
+	if n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	value := atomic.LoadInt64(&a.dense[n-1])
+	atomic.StoreInt64(&a.n, n-1)
+	return int(value), nil
+}
+
+// Returns a newly allocated slice containing the members of the set.
+// Unlike GrowSet, this allocates on every call, since the set may be
+// modified concurrently.
+func (a *AtomicGrowSet) Values() []int {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	n := atomic.LoadInt64(&a.n)
+	result := make([]int, n)
+	for i := range result {
+		result[i] = int(atomic.LoadInt64(&a.dense[i]))
+	}
+
+	return result
+}
@@ -0,0 +1,66 @@
+package intset
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestAtomicGrowSet(t *testing.T) {
+	set := NewAtomicGrowSet(6)
+
+	set.Add(1)
+	set.Add(3)
+	set.Add(4)
+	set.Add(3)
+
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	for _, v := range []int{-1, 0, 2, 5, 6} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	for _, v := range set.Values() {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	popped, err := set.Pop()
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, !set.Contains(popped), "set should not contain popped value")
+
+	err = set.Add(6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	set.Clear()
+	assert(t, set.Size() == 0, "set size should be 0")
+
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestAtomicGrowSetConcurrentReaders(t *testing.T) {
+	const capacity = 1000
+	set := NewAtomicGrowSet(capacity)
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for set.Size() < capacity {
+				n := set.Size()
+				for v := 0; v < n; v++ {
+					if !set.Contains(v) {
+						t.Errorf("set should contain %v", v)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for v := 0; v < capacity; v++ {
+		set.Add(v)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,84 @@
+package intset
+
+// Returns out resized to hold one bit for each of n queries, and
+// cleared. out is grown only if it is too small.
+func batchBuffer(out []uint64, n int) []uint64 {
+	words := (n + 63) / 64
+	if cap(out) < words {
+		out = make([]uint64, words)
+	}
+
+	out = out[:words]
+	clear(out)
+	return out
+}
+
+// Sets bit i of out if values[i] is a member of s, which must be a
+// GrowSet or SparseSet, since it checks the dense array directly.
+func (s *set) containsBatch(values []int, out []uint64) []uint64 {
+	out = batchBuffer(out, len(values))
+	sparse, dense, offset, n := s.sparse, s.dense[:s.n], s.offset, s.n
+	for i, value := range values {
+		value -= offset
+		if uint(value) >= uint(len(sparse)) {
+			continue
+		}
+
+		if index := sparse[value]; uint(index) < uint(n) && dense[index] == value+offset {
+			out[i/64] |= 1 << (i % 64)
+		}
+	}
+
+	return out
+}
+
+// Answers membership for many values in one call, setting bit i of the
+// result, that is bit i%64 of word i/64, if values[i] is a member of
+// the set. The result is written into out, which is grown only if it
+// is too small, so reusing it across calls makes this allocation-free.
+// out may be nil.
+func (g *GrowSet) ContainsBatch(values []int, out []uint64) []uint64 {
+	return (*set)(g).containsBatch(values, out)
+}
+
+// Answers membership for many values in one call, setting bit i of the
+// result, that is bit i%64 of word i/64, if values[i] is a member of
+// the set. The result is written into out, which is grown only if it
+// is too small, so reusing it across calls makes this allocation-free.
+// out may be nil.
+func (s *ShrinkSet) ContainsBatch(values []int, out []uint64) []uint64 {
+	out = batchBuffer(out, len(values))
+	for i, value := range values {
+		if s.Contains(value) {
+			out[i/64] |= 1 << (i % 64)
+		}
+	}
+
+	return out
+}
+
+// Answers membership for many values in one call, setting bit i of the
+// result, that is bit i%64 of word i/64, if values[i] is a member of
+// the set. The result is written into out, which is grown only if it
+// is too small, so reusing it across calls makes this allocation-free.
+// out may be nil.
+func (s *SparseSet) ContainsBatch(values []int, out []uint64) []uint64 {
+	return (*set)(s).containsBatch(values, out)
+}
+
+// Answers membership for many values in one call, setting bit i of the
+// result, that is bit i%64 of word i/64, if values[i] is a member of
+// the set. The result is written into out, which is grown only if it
+// is too small, so reusing it across calls makes this allocation-free.
+// out may be nil.
+func (b *BitSet) ContainsBatch(values []int, out []uint64) []uint64 {
+	out = batchBuffer(out, len(values))
+	words, capacity := b.words, uint(b.capacity)
+	for i, value := range values {
+		if uint(value) < capacity && words[value/64]&(1<<(value%64)) != 0 {
+			out[i/64] |= 1 << (i % 64)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,53 @@
+package intset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestContainsBatch(t *testing.T) {
+	grow := NewGrowSet(200)
+	shrink := NewShrinkSetRange(-100, 100)
+	sparse := NewSparseSetRange(-100, 100)
+	bits := NewBitSet(200)
+	for v := -100; v < 100; v++ {
+		if rand.Intn(2) == 0 {
+			shrink.Remove(v)
+		} else {
+			sparse.Add(v)
+		}
+
+		if v >= 0 && rand.Intn(2) == 0 {
+			grow.Add(v)
+			bits.Add(v)
+		}
+	}
+
+	values := make([]int, 150)
+	for i := range values {
+		values[i] = rand.Intn(300) - 150
+	}
+
+	sets := map[string]interface {
+		Contains(int) bool
+		ContainsBatch([]int, []uint64) []uint64
+	}{"GrowSet": grow, "ShrinkSet": shrink, "SparseSet": sparse, "BitSet": bits}
+
+	for name, set := range sets {
+		out := make([]uint64, 5)
+		out[4] = 1
+		out = set.ContainsBatch(values, out)
+		assert(t, len(out) == 3, "%v: result should have 3 words, has %v", name, len(out))
+		for i, v := range values {
+			got := out[i/64]&(1<<(i%64)) != 0
+			assert(t, got == set.Contains(v), "%v: bit %v for %v should be %v", name, i, v, set.Contains(v))
+		}
+
+		for i := len(values); i < 192; i++ {
+			assert(t, out[i/64]&(1<<(i%64)) == 0, "%v: bit %v past the queries should be clear", name, i)
+		}
+	}
+
+	out := sparse.ContainsBatch(nil, nil)
+	assert(t, len(out) == 0, "no queries should give an empty result")
+}
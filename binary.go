@@ -0,0 +1,150 @@
+package intset
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// The binary encoding of a set is a sequence of unsigned varints: the
+// capacity of the set, the number of members, and then each member,
+// relative to the smallest value the set can hold, in the order of the
+// set's dense array. If the smallest value the set can hold is not
+// zero, it follows as a signed varint. Decoding adds the members back
+// in the same order, so the decoded set pops in the same order as the
+// original.
+
+// Appends the binary encoding of a set to b.
+func appendBinary(b []byte, offset int, capacity int, values []int) []byte {
+	b = binary.AppendUvarint(b, uint64(capacity))
+	b = binary.AppendUvarint(b, uint64(len(values)))
+	for _, v := range values {
+		b = binary.AppendUvarint(b, uint64(v-offset))
+	}
+
+	if offset != 0 {
+		b = binary.AppendVarint(b, int64(offset))
+	}
+
+	return b
+}
+
+// Decodes the binary encoding of a set from data, calling create with
+// the smallest value and the capacity of the set and then add with
+// each member.
+// If data is not a valid encoding, or describes a set with a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned.
+func decodeBinary(data []byte, create func(offset, capacity int), add func(value int) error) error {
+	var header [2]uint64
+	for i := range header {
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v > math.MaxInt {
+			return ErrInvalidEncoding
+		}
+
+		header[i], data = v, data[n:]
+	}
+
+	capacity, count := int(header[0]), int(header[1])
+	if count > capacity || capacity > MaxDecodedCapacity {
+		return ErrInvalidEncoding
+	}
+
+	// The offset comes after the members, so they are checked and
+	// skipped over before any are added.
+	members := data
+	for i := 0; i < count; i++ {
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v >= uint64(capacity) {
+			return ErrInvalidEncoding
+		}
+
+		data = data[n:]
+	}
+
+	offset := int64(0)
+	if len(data) != 0 {
+		var n int
+		offset, n = binary.Varint(data)
+		if n != len(data) || offset+int64(capacity) < offset {
+			return ErrInvalidEncoding
+		}
+	}
+
+	create(int(offset), capacity)
+	for i := 0; i < count; i++ {
+		v, n := binary.Uvarint(members)
+		members = members[n:]
+		add(int(offset) + int(v))
+	}
+
+	return nil
+}
+
+// Appends the binary encoding of the set to b, implementing
+// encoding.BinaryAppender.
+func (g *GrowSet) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, g.offset, len(g.sparse), g.Values()), nil
+}
+
+// Returns the binary encoding of the set, implementing
+// encoding.BinaryMarshaler.
+func (g *GrowSet) MarshalBinary() ([]byte, error) {
+	return g.AppendBinary(nil)
+}
+
+// Replaces the set with one decoded from data, implementing
+// encoding.BinaryUnmarshaler.
+// If data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state.
+func (g *GrowSet) UnmarshalBinary(data []byte) error {
+	return decodeBinary(data, func(offset, capacity int) { *g = *NewGrowSetRange(offset, offset+capacity) }, g.Add)
+}
+
+// Appends the binary encoding of the set to b, implementing
+// encoding.BinaryAppender.
+func (s *ShrinkSet) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, s.offset, len(s.sparse), s.Values()), nil
+}
+
+// Returns the binary encoding of the set, implementing
+// encoding.BinaryMarshaler.
+func (s *ShrinkSet) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// Replaces the set with one decoded from data, implementing
+// encoding.BinaryUnmarshaler. Refilling the decoded set restores every
+// value less than its capacity.
+// If data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state.
+func (s *ShrinkSet) UnmarshalBinary(data []byte) error {
+	create := func(offset, capacity int) {
+		*s = *NewShrinkSetRange(offset, offset+capacity)
+		s.n = 0
+	}
+
+	return decodeBinary(data, create, s.Add)
+}
+
+// Appends the binary encoding of the set to b, implementing
+// encoding.BinaryAppender.
+func (s *SparseSet) AppendBinary(b []byte) ([]byte, error) {
+	return appendBinary(b, s.offset, len(s.sparse), s.Values()), nil
+}
+
+// Returns the binary encoding of the set, implementing
+// encoding.BinaryMarshaler.
+func (s *SparseSet) MarshalBinary() ([]byte, error) {
+	return s.AppendBinary(nil)
+}
+
+// Replaces the set with one decoded from data, implementing
+// encoding.BinaryUnmarshaler.
+// If data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state.
+func (s *SparseSet) UnmarshalBinary(data []byte) error {
+	return decodeBinary(data, func(offset, capacity int) { *s = *NewSparseSetRange(offset, offset+capacity) }, s.Add)
+}
@@ -0,0 +1,93 @@
+package intset
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*GrowSet)(nil)
+	_ encoding.BinaryUnmarshaler = (*GrowSet)(nil)
+	_ encoding.BinaryMarshaler   = (*ShrinkSet)(nil)
+	_ encoding.BinaryUnmarshaler = (*ShrinkSet)(nil)
+	_ encoding.BinaryMarshaler   = (*SparseSet)(nil)
+	_ encoding.BinaryUnmarshaler = (*SparseSet)(nil)
+)
+
+func TestGrowSetBinary(t *testing.T) {
+	set := growSetOf(300, 3, 200, 1)
+	data, err := set.MarshalBinary()
+	assert(t, err == nil, "error is not nil: %v", err)
+
+	var decoded GrowSet
+	err = decoded.UnmarshalBinary(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, slices.Equal(decoded.Values(), set.Values()), "decoded values are %v", decoded.Values())
+	assert(t, len(decoded.sparse) == 300, "decoded capacity should be 300")
+
+	appended, _ := set.AppendBinary([]byte{0xff})
+	assert(t, appended[0] == 0xff && slices.Equal(appended[1:], data), "AppendBinary should append")
+}
+
+func TestShrinkSetBinary(t *testing.T) {
+	set := NewShrinkSet(6)
+	set.Remove(1)
+	set.Remove(4)
+	data, _ := set.MarshalBinary()
+
+	decoded := NewShrinkSet(2)
+	err := decoded.UnmarshalBinary(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, decoded.Contains, decoded.Size(), 6, 0, 2, 3, 5)
+
+	decoded.Refill()
+	assertMembers(t, decoded.Contains, decoded.Size(), 6, 0, 1, 2, 3, 4, 5)
+}
+
+func TestSparseSetBinary(t *testing.T) {
+	set := NewSparseSet(10)
+	set.Add(9)
+	set.Add(0)
+	data, _ := set.MarshalBinary()
+
+	var decoded SparseSet
+	err := decoded.UnmarshalBinary(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, decoded.Contains, decoded.Size(), 10, 0, 9)
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{5},
+		{5, 6, 0, 1, 2, 3, 4, 5},
+		{5, 1, 5},
+		{5, 1, 2, 0x80},
+		{5, 1, 2, 2, 0},
+		{5, 2, 1},
+		binary.AppendUvarint(binary.AppendUvarint(nil, 1<<62), 0),
+		binary.AppendUvarint(binary.AppendUvarint(nil, uint64(MaxDecodedCapacity)+1), 0),
+		binary.AppendVarint([]byte{10, 0}, math.MaxInt64-5),
+	} {
+		var set GrowSet
+		err := set.UnmarshalBinary(data)
+		assert(t, err == ErrInvalidEncoding, "decoding %v should fail, got %v", data, err)
+	}
+}
+
+func TestBinaryWithOffset(t *testing.T) {
+	set := NewGrowSetRange(-5, 5)
+	set.Add(-5)
+	set.Add(4)
+	data, _ := set.MarshalBinary()
+
+	var decoded GrowSet
+	err := decoded.UnmarshalBinary(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, decoded.Equal(set), "decoded set differs")
+	assert(t, errors.Is(decoded.Add(-6), ErrValueOutOfRange), "decoded set should keep its range")
+}
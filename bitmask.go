@@ -0,0 +1,164 @@
+package intset
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Returns the members of all as a bitmask over the values from lo up to,
+// but not including, hi, written into dst.
+func toBitmask(dst []uint64, lo, hi int, all iter.Seq[int]) []uint64 {
+	dst = batchBuffer(dst, hi-lo)
+	for v := range all {
+		v -= lo
+		dst[v/64] |= 1 << (v % 64)
+	}
+
+	return dst
+}
+
+// Returns the position of the highest bit set in mask if it does not
+// fit in a set of the given capacity, or -1 if every bit does.
+func bitmaskOverflow(mask []uint64, capacity int) int {
+	if i := bitmapPrev(mask, 64*len(mask)-1); i >= capacity {
+		return i
+	}
+
+	return -1
+}
+
+// Returns the members of the set as a bitmask, in which bit i, that is
+// bit i%64 of word i/64, is set if the smallest value the set can hold
+// plus i is a member. The result has one bit for each value the set can
+// hold, rounded up to a whole word, and is written into dst, which is
+// grown only if it is too small. dst may be nil.
+// This takes O(n/64 + k) time, where n == capacity and k is the size
+// of the set.
+func (g *GrowSet) ToBitmask(dst []uint64) []uint64 {
+	lo, hi := (*set)(g).bounds()
+	return toBitmask(dst, lo, hi, g.All())
+}
+
+// Replaces the members of the set with those given by mask, in the
+// format returned by ToBitmask. mask may be shorter than the set's
+// capacity, in which case the missing bits are taken to be clear.
+// If mask has a bit set for a value too large to be stored in the set,
+// ErrValueOutOfRange is returned and the set is unchanged, otherwise
+// nil.
+func (g *GrowSet) FromBitmask(mask []uint64) error {
+	lo, _ := (*set)(g).bounds()
+	if i := bitmaskOverflow(mask, g.Capacity()); i >= 0 {
+		return (*set)(g).outOfRange(lo + i)
+	}
+
+	g.Clear()
+	for i := bitmapNext(mask, 0); i >= 0; i = bitmapNext(mask, i+1) {
+		g.Add(lo + i)
+	}
+
+	return nil
+}
+
+// Returns the members of the set as a bitmask, in which bit i, that is
+// bit i%64 of word i/64, is set if the smallest value the set can hold
+// plus i is a member. The result has one bit for each value the set can
+// hold, rounded up to a whole word, and is written into dst, which is
+// grown only if it is too small. dst may be nil.
+// This takes O(n/64 + k) time, where n == capacity and k is the size
+// of the set.
+func (s *ShrinkSet) ToBitmask(dst []uint64) []uint64 {
+	lo, hi := (*set)(s).bounds()
+	return toBitmask(dst, lo, hi, s.All())
+}
+
+// Replaces the members of the set with those given by mask, in the
+// format returned by ToBitmask. mask may be shorter than the set's
+// capacity, in which case the missing bits are taken to be clear.
+// This takes O(n) time, where n == capacity, since every value whose
+// bit is clear must be removed.
+// If mask has a bit set for a value too large to be stored in the set,
+// ErrValueOutOfRange is returned and the set is unchanged, otherwise
+// nil.
+func (s *ShrinkSet) FromBitmask(mask []uint64) error {
+	lo, hi := (*set)(s).bounds()
+	if i := bitmaskOverflow(mask, hi-lo); i >= 0 {
+		return (*set)(s).outOfRange(lo + i)
+	}
+
+	s.Refill()
+	for i := 0; i < hi-lo; i++ {
+		if i/64 >= len(mask) || mask[i/64]&(1<<(i%64)) == 0 {
+			s.Remove(lo + i)
+		}
+	}
+
+	return nil
+}
+
+// Returns the members of the set as a bitmask, in which bit i, that is
+// bit i%64 of word i/64, is set if the smallest value the set can hold
+// plus i is a member. The result has one bit for each value the set can
+// hold, rounded up to a whole word, and is written into dst, which is
+// grown only if it is too small. dst may be nil.
+// This takes O(n/64 + k) time, where n == capacity and k is the size
+// of the set.
+func (s *SparseSet) ToBitmask(dst []uint64) []uint64 {
+	lo, hi := (*set)(s).bounds()
+	return toBitmask(dst, lo, hi, s.All())
+}
+
+// Replaces the members of the set with those given by mask, in the
+// format returned by ToBitmask. mask may be shorter than the set's
+// capacity, in which case the missing bits are taken to be clear.
+// If mask has a bit set for a value too large to be stored in the set,
+// ErrValueOutOfRange is returned and the set is unchanged, otherwise
+// nil.
+func (s *SparseSet) FromBitmask(mask []uint64) error {
+	lo, _ := (*set)(s).bounds()
+	if i := bitmaskOverflow(mask, s.Capacity()); i >= 0 {
+		return (*set)(s).outOfRange(lo + i)
+	}
+
+	s.Clear()
+	for i := bitmapNext(mask, 0); i >= 0; i = bitmapNext(mask, i+1) {
+		s.Add(lo + i)
+	}
+
+	return nil
+}
+
+// Returns the members of the set as a bitmask, in which bit i, that is
+// bit i%64 of word i/64, is set if i is a member. This is a copy of the
+// set's own bitmap, written into dst, which is grown only if it is too
+// small. dst may be nil.
+func (b *BitSet) ToBitmask(dst []uint64) []uint64 {
+	return append(dst[:0], b.words...)
+}
+
+// Replaces the members of the set with those given by mask, in the
+// format returned by ToBitmask, copying it into the set's bitmap.
+// mask may be shorter than the set's capacity, in which case the
+// missing bits are taken to be clear.
+// If mask has a bit set for a value too large to be stored in the set,
+// ErrValueOutOfRange is returned and the set is unchanged, otherwise
+// nil.
+func (b *BitSet) FromBitmask(mask []uint64) error {
+	if i := bitmaskOverflow(mask, b.capacity); i >= 0 {
+		return outOfRange(i, 0, b.capacity)
+	}
+
+	b.Clear()
+	copy(b.words, mask)
+	for w, word := range b.words {
+		if word == 0 {
+			continue
+		}
+
+		b.n += bits.OnesCount64(word)
+		for i, level := w, b.levels[1:]; len(level) > 0; i, level = i/64, level[1:] {
+			level[0][i/64] |= 1 << (i % 64)
+		}
+	}
+
+	return nil
+}
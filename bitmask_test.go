@@ -0,0 +1,65 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBitmask(t *testing.T) {
+	grow := growSetOf(130, 0, 5, 64, 129)
+	mask := grow.ToBitmask(nil)
+	assert(t, slices.Equal(mask, []uint64{1 | 1<<5, 1, 2}), "mask is %x", mask)
+
+	sparse := NewSparseSetRange(-10, 110)
+	err := sparse.FromBitmask(mask)
+	assertMembers(t, sparse.Contains, sparse.Size(), 0)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	err = sparse.FromBitmask(mask[:2])
+	assert(t, err == nil, "error is not nil: %v", err)
+	for _, v := range []int{-10, -5, 54} {
+		assert(t, sparse.Contains(v), "%v should be a member", v)
+	}
+
+	assert(t, sparse.Size() == 3, "size should be 3, is %v", sparse.Size())
+	assert(t, slices.Equal(sparse.ToBitmask(nil), []uint64{1 | 1<<5, 1}), "round trip should preserve the mask")
+
+	shrink := NewShrinkSet(130)
+	assert(t, shrink.FromBitmask(mask) == nil, "error should be nil")
+	assertMembers(t, shrink.Contains, shrink.Size(), 130, 0, 5, 64, 129)
+	min, _ := shrink.Min()
+	assert(t, min == 0, "min should be 0, is %v", min)
+
+	grow = NewGrowSet(130)
+	assert(t, grow.FromBitmask([]uint64{6}) == nil, "error should be nil")
+	max, _ := grow.Max()
+	assertMembers(t, grow.Contains, grow.Size(), 130, 1, 2)
+	assert(t, max == 2, "max should be 2, is %v", max)
+}
+
+func TestBitSetBitmask(t *testing.T) {
+	b := NewBitSet(300000)
+	mask := make([]uint64, len(b.words))
+	reference := NewSparseSet(300000)
+	for i := 0; i < 1000; i++ {
+		v := rand.Intn(300000)
+		mask[v/64] |= 1 << (v % 64)
+		reference.Add(v)
+	}
+
+	b.Add(5)
+	assert(t, b.FromBitmask(mask) == nil, "error should be nil")
+	assert(t, b.Size() == reference.Size(), "size should be %v, is %v", reference.Size(), b.Size())
+	assert(t, slices.Equal(b.Values(), slices.Sorted(reference.All())), "values should match")
+
+	min, _ := b.Min()
+	max, _ := b.Max()
+	sorted := slices.Sorted(reference.All())
+	assert(t, min == sorted[0] && max == sorted[len(sorted)-1], "min and max should be the extremes")
+	assert(t, slices.Equal(b.ToBitmask(nil), mask), "round trip should preserve the mask")
+
+	err := NewBitSet(10).FromBitmask([]uint64{1 << 10})
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
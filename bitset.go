@@ -0,0 +1,260 @@
+package intset
+
+import (
+	"iter"
+	"math/bits"
+	"unsafe"
+)
+
+// A BitSet stores its members as a bitmap, one bit per value the set
+// can hold. For dense sets this uses 64 times less memory than the
+// other set types. It supports the following operations with the
+// associated time complexity:
+//
+//   Add(n)    - Add integer n to the set, in O(1) time.
+//   Remove(n) - Remove n from the set, in O(1) time.
+//   Clear()   - Removes all elements from the set, in O(n/64) time.
+//   Min()     - Return the smallest member, in O(log64 n) time.
+//   Pop()     - Remove the smallest member, in O(log64 n) time.
+//
+// Above the bitmap the set keeps a hierarchy of summary bitmaps, each
+// with one bit per word of the level below, set if that word is
+// nonzero, up to a level of a single word. Searches for members skip
+// empty regions by consulting the summaries, so they take time
+// proportional to the height of the hierarchy, at most four levels for
+// a capacity of 2^24 and five for 2^30, rather than to the distance
+// scanned. Values still allocates a new slice on every call.
+type BitSet struct {
+	n        int
+	capacity int
+	words    []uint64
+	levels   [][]uint64
+}
+
+// Allocate a new BitSet.
+// The resulting set will be able to store the integers less than
+// capacity.
+func NewBitSet(capacity int) *BitSet {
+	validateCapacity(capacity)
+	words := make([]uint64, (capacity+63)/64)
+	levels := [][]uint64{words}
+	for n := len(words); n > 1; {
+		n = (n + 63) / 64
+		levels = append(levels, make([]uint64, n))
+	}
+
+	return &BitSet{
+		capacity: capacity,
+		words:    words,
+		levels:   levels,
+	}
+}
+
+// Sets bit i of the bitmap, marking its word as nonzero in the
+// summaries above it.
+func (b *BitSet) set(i int) {
+	for _, level := range b.levels {
+		word := level[i/64]
+		level[i/64] = word | 1<<(i%64)
+		if word != 0 {
+			return
+		}
+
+		i /= 64
+	}
+}
+
+// Clears bit i of the bitmap, marking its word as empty in the
+// summaries above it if no bits remain.
+func (b *BitSet) unset(i int) {
+	for _, level := range b.levels {
+		level[i/64] &^= 1 << (i % 64)
+		if level[i/64] != 0 {
+			return
+		}
+
+		i /= 64
+	}
+}
+
+// Returns the position of the first bit set in level k of the
+// hierarchy at or after i, or -1 if there is none.
+func (b *BitSet) next(k, i int) int {
+	if len(b.levels) == 0 {
+		// The zero value has no hierarchy.
+		return -1
+	}
+
+	level := b.levels[k]
+	i = max(i, 0)
+	w := i / 64
+	if w >= len(level) {
+		return -1
+	}
+
+	if word := level[w] &^ (1<<(i%64) - 1); word != 0 {
+		return 64*w + bits.TrailingZeros64(word)
+	}
+
+	if k+1 == len(b.levels) {
+		return -1
+	}
+
+	w = b.next(k+1, w+1)
+	if w < 0 {
+		return -1
+	}
+
+	return 64*w + bits.TrailingZeros64(level[w])
+}
+
+// Returns the position of the last bit set in level k of the hierarchy
+// at or before i, or -1 if there is none.
+func (b *BitSet) prev(k, i int) int {
+	if len(b.levels) == 0 {
+		return -1
+	}
+
+	level := b.levels[k]
+	if i < 0 || len(level) == 0 {
+		return -1
+	}
+
+	i = min(i, 64*len(level)-1)
+	w := i / 64
+	if word := level[w] & (^uint64(0) >> (63 - i%64)); word != 0 {
+		return 64*w + 63 - bits.LeadingZeros64(word)
+	}
+
+	if k+1 == len(b.levels) {
+		return -1
+	}
+
+	w = b.prev(k+1, w-1)
+	if w < 0 {
+		return -1
+	}
+
+	return 64*w + 63 - bits.LeadingZeros64(level[w])
+}
+
+// Returns true if value is a member of the set.
+func (b *BitSet) Contains(value int) bool {
+	if value < 0 || value >= b.capacity {
+		return false
+	}
+
+	return b.words[value/64]&(1<<(value%64)) != 0
+}
+
+// Removes all elements from the set.
+func (b *BitSet) Clear() {
+	for _, level := range b.levels {
+		clear(level)
+	}
+
+	b.n = 0
+}
+
+// Returns the size of the set.
+func (b *BitSet) Size() int {
+	return b.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (b *BitSet) Capacity() int {
+	return b.capacity
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (b *BitSet) Add(value int) error {
+	if value < 0 || value >= b.capacity {
+		return outOfRange(value, 0, b.capacity)
+	}
+
+	if !b.Contains(value) {
+		b.set(value)
+		b.n++
+	}
+
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (b *BitSet) Remove(item int) {
+	if b.Contains(item) {
+		b.unset(item)
+		b.n--
+	}
+}
+
+// Returns the smallest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (b *BitSet) Min() (int, error) {
+	if b.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return b.next(0, 0), nil
+}
+
+// Returns the largest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (b *BitSet) Max() (int, error) {
+	if b.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return b.prev(0, b.capacity-1), nil
+}
+
+// Remove and return the smallest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (b *BitSet) Pop() (int, error) {
+	value, err := b.Min()
+	if err == nil {
+		b.unset(value)
+		b.n--
+	}
+
+	return value, err
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (b *BitSet) Values() []int {
+	result := make([]int, 0, b.n)
+	for v := range b.All() {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members of the set, in increasing
+// order. The member being visited may safely be removed during
+// iteration.
+// Iteration does not allocate.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for v := b.next(0, 0); v >= 0; v = b.next(0, v+1) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// bitmap and summaries.
+func (b *BitSet) MemoryUsage() int {
+	result := int(unsafe.Sizeof(*b)) + int(unsafe.Sizeof(b.levels[0]))*cap(b.levels)
+	for _, level := range b.levels {
+		result += 8 * cap(level)
+	}
+
+	return result
+}
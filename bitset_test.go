@@ -0,0 +1,142 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	set := NewBitSet(130)
+
+	for _, v := range []int{0, 63, 64, 129, 64} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, set.Size() == 4, "set size should be 4")
+	assert(t, set.Capacity() == 130, "set capacity should be 130")
+
+	for _, v := range []int{-1, 1, 62, 65, 128, 130} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	err := set.Add(130)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	set.Remove(63)
+	set.Remove(63)
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	values := set.Values()
+	assert(t, slices.Equal(values, []int{0, 64, 129}), "values should be [0 64 129], got %v", values)
+
+	for _, want := range []int{0, 64, 129} {
+		v, err := set.Pop()
+		assert(t, err == nil && v == want, "pop should return %v, got %v", want, v)
+	}
+
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestBitSetAll(t *testing.T) {
+	set := NewBitSet(200)
+	for v := 0; v < 200; v += 3 {
+		set.Add(v)
+	}
+
+	var seen []int
+	for v := range set.All() {
+		set.Remove(v)
+		seen = append(seen, v)
+	}
+
+	assert(t, len(seen) == 67, "All should visit 67 members, visited %v", len(seen))
+	assert(t, slices.IsSorted(seen), "All should visit members in order")
+	assert(t, set.Size() == 0, "set should be empty")
+
+	set.Add(5)
+	set.Clear()
+	assert(t, set.Size() == 0 && !set.Contains(5), "set should be empty after Clear")
+}
+
+func TestBitSetMemoryUsage(t *testing.T) {
+	assert(t, NewBitSet(6400).MemoryUsage() < NewSparseSet(6400).MemoryUsage()/64+100,
+		"BitSet should use about 64 times less memory")
+}
+
+func TestBitSetMinMax(t *testing.T) {
+	set := NewBitSet(300000)
+	_, err := set.Min()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, err = set.Max()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(250000)
+	set.Add(70)
+	set.Add(299999)
+	min, _ := set.Min()
+	max, _ := set.Max()
+	assert(t, min == 70 && max == 299999, "min and max should be 70 and 299999, are %v and %v", min, max)
+
+	next, ok := set.NextAfter(70)
+	assert(t, ok && next == 250000, "next after 70 should be 250000, is %v", next)
+	prev, ok := set.PrevBefore(250000)
+	assert(t, ok && prev == 70, "previous before 250000 should be 70, is %v", prev)
+
+	set.Remove(299999)
+	max, _ = set.Max()
+	assert(t, max == 250000, "max should be 250000, is %v", max)
+	v, _ := set.Pop()
+	assert(t, v == 70, "pop should return 70, returned %v", v)
+	set.Remove(250000)
+	_, err = set.Min()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestBitSetSummaryRandom(t *testing.T) {
+	for _, capacity := range []int{1, 64, 4097, 300000} {
+		set := NewBitSet(capacity)
+		reference := NewSparseSet(capacity)
+		for i := 0; i < 2000; i++ {
+			v := rand.Intn(capacity)
+			if rand.Intn(2) == 0 {
+				set.Remove(v)
+				reference.Remove(v)
+			} else {
+				set.Add(v)
+				reference.Add(v)
+			}
+
+			q := rand.Intn(capacity+2) - 1
+			next, ok := set.NextAfter(q)
+			wantNext, wantOk := reference.NextAfter(q)
+			assert(t, ok == wantOk && next == wantNext, "next after %v should be %v, is %v", q, wantNext, next)
+			prev, ok := set.PrevBefore(q)
+			wantPrev, wantOk := reference.PrevBefore(q)
+			assert(t, ok == wantOk && prev == wantPrev, "previous before %v should be %v, is %v", q, wantPrev, prev)
+		}
+
+		assert(t, slices.Equal(set.Values(), slices.Sorted(reference.All())), "values should match")
+	}
+}
+
+func TestBitSetZeroValue(t *testing.T) {
+	var b BitSet
+	assert(t, b.Size() == 0 && !b.Contains(0), "zero value should be empty")
+	assert(t, len(b.Values()) == 0, "zero value should have no values")
+	for range b.All() {
+		t.Fatal("zero value should yield no members")
+	}
+
+	_, ok := b.NextAfter(-1)
+	assert(t, !ok, "zero value should have no next member")
+	_, ok = b.PrevBefore(10)
+	assert(t, !ok, "zero value should have no previous member")
+	assert(t, len(b.ValuesInRange(0, 10, nil)) == 0, "zero value should have no values in range")
+
+	_, err := b.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
@@ -0,0 +1,125 @@
+package intset
+
+// Bitset is the subset of the methods of *bitset.BitSet, from the
+// github.com/bits-and-blooms/bitset package, used by BitsetAdapter and
+// the conversion functions below. It is parameterized by the bitset
+// type itself, since Set and Clear return the receiver, so that this
+// package need not depend on that one: *bitset.BitSet satisfies
+// Bitset[*bitset.BitSet].
+//
+// That package's bitmaps use the same layout as ToBitmask and
+// FromBitmask, so whole bitmaps can also be exchanged without
+// conversion, using bitset.From(s.ToBitmask(nil)) and
+// s.FromBitmask(b.Words()).
+type Bitset[B any] interface {
+	Test(i uint) bool
+	Set(i uint) B
+	Clear(i uint) B
+	Count() uint
+	NextSet(i uint) (uint, bool)
+}
+
+// A BitsetAdapter wraps a Bitset so that it satisfies IntSet and Adder,
+// letting code written against this package use an existing bitmap
+// while a codebase migrates. Operations take the time the underlying
+// bitset takes; in particular Size is O(n/64), where n is the length of
+// the bitset, and Values allocates a new slice on every call.
+type BitsetAdapter[B Bitset[B]] struct {
+	bits B
+}
+
+// Returns a BitsetAdapter wrapping bits, which continues to be used
+// and modified by the adapter.
+func NewBitsetAdapter[B Bitset[B]](bits B) *BitsetAdapter[B] {
+	return &BitsetAdapter[B]{bits: bits}
+}
+
+// Returns the bitset wrapped by the adapter.
+func (a *BitsetAdapter[B]) Bitset() B {
+	return a.bits
+}
+
+// Returns true if value is a member of the set.
+func (a *BitsetAdapter[B]) Contains(value int) bool {
+	return value >= 0 && a.bits.Test(uint(value))
+}
+
+// Returns the size of the set.
+func (a *BitsetAdapter[B]) Size() int {
+	return int(a.bits.Count())
+}
+
+// Adds value to the set. Adding the same value multiple times is not an
+// error. The bitset grows as needed, as it does for Set.
+// If value is less than zero, ErrValueOutOfRange is returned, otherwise
+// nil.
+func (a *BitsetAdapter[B]) Add(value int) error {
+	if value < 0 {
+		return outOfRange(value, 0, int(^uint(0)>>1))
+	}
+
+	a.bits.Set(uint(value))
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (a *BitsetAdapter[B]) Remove(item int) {
+	if item >= 0 {
+		a.bits.Clear(uint(item))
+	}
+}
+
+// Remove and return the smallest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (a *BitsetAdapter[B]) Pop() (int, error) {
+	i, ok := a.bits.NextSet(0)
+	if !ok {
+		return 0, ErrEmptySet
+	}
+
+	a.bits.Clear(i)
+	return int(i), nil
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (a *BitsetAdapter[B]) Values() []int {
+	var result []int
+	for i, ok := a.bits.NextSet(0); ok; i, ok = a.bits.NextSet(i + 1) {
+		result = append(result, int(i))
+	}
+
+	return result
+}
+
+// Sets the bit of dst for each member of s, returning dst.
+// If s has a negative member, which dst cannot hold, the result will be
+// dst with the other members set and error will be ErrValueOutOfRange.
+func ToBitset[B Bitset[B]](dst B, s IntSet) (B, error) {
+	var err error
+	for _, v := range s.Values() {
+		if v < 0 {
+			err = outOfRange(v, 0, int(^uint(0)>>1))
+			continue
+		}
+
+		dst.Set(uint(v))
+	}
+
+	return dst, err
+}
+
+// Adds each value whose bit is set in src to dst.
+// If dst cannot hold one of the values, the error from dst.Add is
+// returned after the rest have been added, otherwise nil.
+func FromBitset[B Bitset[B]](dst Adder, src B) error {
+	var err error
+	for i, ok := src.NextSet(0); ok; i, ok = src.NextSet(i + 1) {
+		if e := dst.Add(int(i)); e != nil {
+			err = e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,105 @@
+package intset
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+// A minimal stand-in for *bitset.BitSet from
+// github.com/bits-and-blooms/bitset, with the same method signatures and
+// the same habit of growing on Set.
+type testBitset struct {
+	words []uint64
+}
+
+func (b *testBitset) Test(i uint) bool {
+	return int(i/64) < len(b.words) && b.words[i/64]&(1<<(i%64)) != 0
+}
+
+func (b *testBitset) Set(i uint) *testBitset {
+	for int(i/64) >= len(b.words) {
+		b.words = append(b.words, 0)
+	}
+
+	b.words[i/64] |= 1 << (i % 64)
+	return b
+}
+
+func (b *testBitset) Clear(i uint) *testBitset {
+	if int(i/64) < len(b.words) {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+
+	return b
+}
+
+func (b *testBitset) Count() uint {
+	count := 0
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+
+	return uint(count)
+}
+
+func (b *testBitset) NextSet(i uint) (uint, bool) {
+	if next := bitmapNext(b.words, int(i)); next >= 0 {
+		return uint(next), true
+	}
+
+	return 0, false
+}
+
+var (
+	_ IntSet = (*BitsetAdapter[*testBitset])(nil)
+	_ Adder  = (*BitsetAdapter[*testBitset])(nil)
+)
+
+func TestBitsetAdapter(t *testing.T) {
+	a := NewBitsetAdapter(&testBitset{})
+	for _, v := range []int{3, 200, 64, 3} {
+		assert(t, a.Add(v) == nil, "error adding %v", v)
+	}
+
+	err := a.Add(-1)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, a.Contains, a.Size(), 256, 3, 64, 200)
+	assert(t, slices.Equal(a.Values(), []int{3, 64, 200}), "values are %v", a.Values())
+
+	v, err := a.Pop()
+	assert(t, err == nil && v == 3, "pop should return 3, returned %v", v)
+	a.Remove(200)
+	a.Remove(-5)
+	assert(t, slices.Equal(a.Bitset().words, []uint64{0, 1, 0, 0}), "bitset words are %v", a.Bitset().words)
+
+	a.Remove(64)
+	_, err = a.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestBitsetConversions(t *testing.T) {
+	src := NewSparseSetRange(-5, 100)
+	src.AddAll(1, 50, 99)
+
+	b, err := ToBitset(&testBitset{}, src)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, b.Count() == 3 && b.Test(50), "bitset should hold the members")
+
+	dst := NewGrowSet(100)
+	assert(t, FromBitset(dst, b) == nil, "error should be nil")
+	assertMembers(t, dst.Contains, dst.Size(), 100, 1, 50, 99)
+
+	src.Add(-3)
+	_, err = ToBitset(&testBitset{}, src)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	b.Set(150)
+	err = FromBitset(NewGrowSet(100), b)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	bits := NewBitSet(100)
+	assert(t, bits.FromBitmask((&testBitset{words: dst.ToBitmask(nil)}).words) == nil, "words should be interchangeable")
+	assert(t, slices.Equal(bits.Values(), []int{1, 50, 99}), "values are %v", bits.Values())
+}
@@ -0,0 +1,254 @@
+package intset
+
+import (
+	"fmt"
+)
+
+// Returned by bulk operations when some of the values given to them are
+// out of range. The remaining values are still processed.
+// errors.Is reports a RejectedValuesError as ErrValueOutOfRange.
+type RejectedValuesError struct {
+	// The values that were rejected, in the order they were given.
+	Values []int
+}
+
+// Returns a description of the error, listing the rejected values.
+func (e *RejectedValuesError) Error() string {
+	return fmt.Sprintf("%d values out of range: %v", len(e.Values), e.Values)
+}
+
+// Reports whether target is ErrValueOutOfRange, for errors.Is.
+func (e *RejectedValuesError) Is(target error) bool {
+	return target == ErrValueOutOfRange
+}
+
+// Checks that every one of values fits in s, returning nil if they all
+// do and a *RejectedValuesError otherwise. If s is a zero value, the
+// result is ErrUninitialized.
+func (s *set) checkRange(values []int) error {
+	if s.sparse == nil && len(values) > 0 {
+		return ErrUninitialized
+	}
+
+	var rejected []int
+	for _, v := range values {
+		if !s.inRange(v) {
+			rejected = append(rejected, v)
+		}
+	}
+
+	if rejected != nil {
+		return &RejectedValuesError{Values: rejected}
+	}
+
+	return nil
+}
+
+// Checks that every integer from lo up to, but not including, hi fits in
+// s, returning nil if they all do and a *RangeError for the first end
+// of the span that does not otherwise.
+func (s *set) checkSpan(lo, hi int) error {
+	lowest, limit := s.bounds()
+	switch {
+	case lo < lowest:
+		return s.outOfRange(lo)
+	case hi > limit:
+		return s.outOfRange(hi - 1)
+	}
+
+	return nil
+}
+
+// Adds each of values to the set. A slice can be passed as
+// AddAll(slice...).
+// Values that are too small or too large to be stored in the set
+// are skipped, and reported together in a *RejectedValuesError;
+// otherwise the result is nil.
+func (g *GrowSet) AddAll(values ...int) error {
+	err := (*set)(g).checkRange(values)
+	for _, v := range values {
+		g.Add(v)
+	}
+
+	return err
+}
+
+// Adds each of values back to the set. A slice can be passed as
+// AddAll(slice...).
+// Values that are too small or too large to have been in the set
+// are skipped, and reported together in a *RejectedValuesError;
+// otherwise the result is nil.
+func (s *ShrinkSet) AddAll(values ...int) error {
+	err := (*set)(s).checkRange(values)
+	for _, v := range values {
+		s.Add(v)
+	}
+
+	return err
+}
+
+// Removes each of values from the set. A slice can be passed as
+// RemoveAll(slice...). It is not an error to remove values that are not
+// in the set.
+func (s *ShrinkSet) RemoveAll(values ...int) {
+	for _, v := range values {
+		s.Remove(v)
+	}
+}
+
+// Adds each of values to the set. A slice can be passed as
+// AddAll(slice...).
+// Values that are too small or too large to be stored in the set
+// are skipped, and reported together in a *RejectedValuesError;
+// otherwise the result is nil.
+func (s *SparseSet) AddAll(values ...int) error {
+	err := (*set)(s).checkRange(values)
+	for _, v := range values {
+		s.Add(v)
+	}
+
+	return err
+}
+
+// Removes each of values from the set. A slice can be passed as
+// RemoveAll(slice...). It is not an error to remove values that are not
+// in the set.
+func (s *SparseSet) RemoveAll(values ...int) {
+	for _, v := range values {
+		s.Remove(v)
+	}
+}
+
+// Adds every integer from lo up to, but not including, hi to the set.
+// If any of them is too small or too large to be stored in the
+// set, nothing is added and ErrValueOutOfRange is returned, otherwise
+// nil. New members are written to consecutive dense positions, so this
+// is faster than adding each value individually.
+func (g *GrowSet) AddRange(lo, hi int) error {
+	if lo >= hi {
+		return nil
+	}
+
+	if err := (*set)(g).checkSpan(lo, hi); err != nil {
+		return err
+	}
+
+	for v := lo; v < hi; v++ {
+		if !g.Contains(v) {
+			(*set)(g).own()
+			g.extend(v)
+			g.dense[g.n] = v
+			g.sparse[v-g.offset] = g.n
+			g.n++
+		}
+	}
+
+	return nil
+}
+
+// Adds every integer from lo up to, but not including, hi back to the
+// set. If any of them is too small or too large to have been in
+// the set, nothing is added and ErrValueOutOfRange is returned,
+// otherwise nil.
+func (s *ShrinkSet) AddRange(lo, hi int) error {
+	if lo >= hi {
+		return nil
+	}
+
+	if err := (*set)(s).checkSpan(lo, hi); err != nil {
+		return err
+	}
+
+	for v := lo; v < hi; v++ {
+		s.Add(v)
+	}
+
+	return nil
+}
+
+// Removes every integer from lo up to, but not including, hi from the
+// set. It is not an error for the range to include values that are not
+// in the set.
+func (s *ShrinkSet) RemoveRange(lo, hi int) {
+	lowest, limit := (*set)(s).bounds()
+	for v := max(lo, lowest); v < min(hi, limit); v++ {
+		s.Remove(v)
+	}
+}
+
+// Adds every integer from lo up to, but not including, hi to the set.
+// If any of them is too small or too large to be stored in the
+// set, nothing is added and ErrValueOutOfRange is returned, otherwise
+// nil.
+func (s *SparseSet) AddRange(lo, hi int) error {
+	if lo >= hi {
+		return nil
+	}
+
+	if err := (*set)(s).checkSpan(lo, hi); err != nil {
+		return err
+	}
+
+	for v := lo; v < hi; v++ {
+		if !s.Contains(v) {
+			(*set)(s).own()
+			s.dense[s.n] = v
+			s.sparse[v-s.offset] = s.n
+			s.n++
+		}
+	}
+
+	return nil
+}
+
+// Removes every integer from lo up to, but not including, hi from the
+// set. It is not an error for the range to include values that are not
+// in the set.
+func (s *SparseSet) RemoveRange(lo, hi int) {
+	lowest, limit := (*set)(s).bounds()
+	for v := max(lo, lowest); v < min(hi, limit); v++ {
+		s.Remove(v)
+	}
+}
+
+// Returns true if every one of values is a member of the set, stopping
+// at the first one that is not. A slice can be passed as
+// ContainsAll(slice...). With no values, the result is true.
+func (g *GrowSet) ContainsAll(values ...int) bool {
+	return allContained(values, g.Contains)
+}
+
+// Returns true if any of values is a member of the set, stopping at the
+// first one that is. A slice can be passed as ContainsAny(slice...).
+// With no values, the result is false.
+func (g *GrowSet) ContainsAny(values ...int) bool {
+	return anyContained(values, g.Contains)
+}
+
+// Returns true if every one of values is a member of the set, stopping
+// at the first one that is not. A slice can be passed as
+// ContainsAll(slice...). With no values, the result is true.
+func (s *ShrinkSet) ContainsAll(values ...int) bool {
+	return allContained(values, s.Contains)
+}
+
+// Returns true if any of values is a member of the set, stopping at the
+// first one that is. A slice can be passed as ContainsAny(slice...).
+// With no values, the result is false.
+func (s *ShrinkSet) ContainsAny(values ...int) bool {
+	return anyContained(values, s.Contains)
+}
+
+// Returns true if every one of values is a member of the set, stopping
+// at the first one that is not. A slice can be passed as
+// ContainsAll(slice...). With no values, the result is true.
+func (s *SparseSet) ContainsAll(values ...int) bool {
+	return allContained(values, s.Contains)
+}
+
+// Returns true if any of values is a member of the set, stopping at the
+// first one that is. A slice can be passed as ContainsAny(slice...).
+// With no values, the result is false.
+func (s *SparseSet) ContainsAny(values ...int) bool {
+	return anyContained(values, s.Contains)
+}
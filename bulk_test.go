@@ -0,0 +1,99 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGrowSetAddAll(t *testing.T) {
+	set := NewGrowSet(6)
+	err := set.AddAll(1, 3, 3, 5)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 6, 1, 3, 5)
+
+	err = set.AddAll([]int{0, 7, -1, 2}...)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	var rejected *RejectedValuesError
+	assert(t, errors.As(err, &rejected), "error should be a RejectedValuesError")
+	assert(t, slices.Equal(rejected.Values, []int{7, -1}), "rejected values are %v", rejected.Values)
+	assertMembers(t, set.Contains, set.Size(), 6, 0, 1, 2, 3, 5)
+}
+
+func TestShrinkSetAddAllRemoveAll(t *testing.T) {
+	set := NewShrinkSet(6)
+	set.RemoveAll(0, 2, 4, 9)
+	assertMembers(t, set.Contains, set.Size(), 6, 1, 3, 5)
+
+	err := set.AddAll(2, 6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, set.Contains, set.Size(), 6, 1, 2, 3, 5)
+}
+
+func TestSparseSetAddAllRemoveAll(t *testing.T) {
+	set := NewSparseSet(10)
+	err := set.AddAll(1, 2, 3, 8)
+	assert(t, err == nil, "error is not nil: %v", err)
+
+	set.RemoveAll(2, 8)
+	assertMembers(t, set.Contains, set.Size(), 10, 1, 3)
+}
+
+func TestGrowSetAddRange(t *testing.T) {
+	set := growSetOf(10, 4)
+	err := set.AddRange(2, 6)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 10, 2, 3, 4, 5)
+
+	max, _ := set.Max()
+	assert(t, max == 5, "max should be 5, is %v", max)
+
+	err = set.AddRange(8, 11)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, set.Contains, set.Size(), 10, 2, 3, 4, 5)
+
+	err = set.AddRange(5, 5)
+	assert(t, err == nil, "empty range should not be an error")
+}
+
+func TestShrinkSetAddRangeRemoveRange(t *testing.T) {
+	set := NewShrinkSet(8)
+	set.RemoveRange(-3, 5)
+	assertMembers(t, set.Contains, set.Size(), 8, 5, 6, 7)
+
+	err := set.AddRange(1, 3)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 8, 1, 2, 5, 6, 7)
+
+	err = set.AddRange(-1, 3)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestSparseSetAddRangeRemoveRange(t *testing.T) {
+	set := NewSparseSet(10)
+	set.AddRange(0, 10)
+	set.RemoveRange(3, 7)
+	set.RemoveRange(9, 20)
+	assertMembers(t, set.Contains, set.Size(), 10, 0, 1, 2, 7, 8)
+}
+
+func TestContainsAllAny(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3)
+	assert(t, g.ContainsAll(1, 3), "set should contain 1 and 3")
+	assert(t, !g.ContainsAll(1, 4), "set should not contain 4")
+	assert(t, g.ContainsAll(), "every set contains nothing")
+	assert(t, g.ContainsAny(-1, 11, 2), "set should contain 2")
+	assert(t, !g.ContainsAny([]int{0, 4, 10}...), "set should contain none of 0, 4, 10")
+	assert(t, !g.ContainsAny(), "no value means no match")
+
+	s := NewShrinkSet(5)
+	s.Remove(2)
+	assert(t, s.ContainsAll(0, 1, 3, 4) && !s.ContainsAll(2), "ContainsAll is wrong for ShrinkSet")
+	assert(t, s.ContainsAny(2, 3) && !s.ContainsAny(2, 5), "ContainsAny is wrong for ShrinkSet")
+
+	p := NewSparseSet(5)
+	p.Add(4)
+	assert(t, p.ContainsAll(4) && !p.ContainsAll(4, 0), "ContainsAll is wrong for SparseSet")
+	assert(t, p.ContainsAny(0, 4) && !p.ContainsAny(0), "ContainsAny is wrong for SparseSet")
+}
@@ -0,0 +1,59 @@
+package intset
+
+// A Checkpoint records the state of a GrowSet or ShrinkSet, so that the
+// set can later be rolled back to it.
+type Checkpoint struct {
+	n        int
+	min      int
+	max      int
+	stale    bool
+	inverted bool
+}
+
+// Returns a Checkpoint recording the current state of the set, in O(1)
+// time.
+func (g *GrowSet) Checkpoint() Checkpoint {
+	return Checkpoint{n: g.n, min: g.min, max: g.max, stale: g.stale}
+}
+
+// Restores the set to the state recorded by c, removing every value
+// added since, in O(1) time. Because a GrowSet appends new members to
+// its dense array, the members at the time of the checkpoint are still
+// at its front, which makes this ideal for backtracking search.
+// This is only valid if the set has not had members popped, cleared,
+// or otherwise removed since c was taken. If the set is smaller than it
+// was at the checkpoint, it is left unchanged and ErrInvalidCheckpoint
+// is returned, otherwise nil.
+func (g *GrowSet) Rollback(c Checkpoint) error {
+	if c.n > g.n {
+		return ErrInvalidCheckpoint
+	}
+
+	g.mods += uint(g.n - c.n)
+	g.n, g.min, g.max, g.stale = c.n, c.min, c.max, c.stale
+	return nil
+}
+
+// Returns a Checkpoint recording the current state of the set, in O(1)
+// time.
+func (s *ShrinkSet) Checkpoint() Checkpoint {
+	return Checkpoint{n: s.n, min: s.min, max: s.max, inverted: s.inverted}
+}
+
+// Restores the set to the state recorded by c, adding back every value
+// removed since, in O(1) time. Because a ShrinkSet moves each removed
+// value to just past the end of its members, the values removed since
+// the checkpoint are exactly those that follow the members.
+// This is only valid if the set has not had values added, refilled, or
+// otherwise restored since c was taken. If the set is larger than it
+// was at the checkpoint, or has been inverted an odd number of times
+// since, it is left unchanged and ErrInvalidCheckpoint is returned,
+// otherwise nil.
+func (s *ShrinkSet) Rollback(c Checkpoint) error {
+	if c.n < s.n || c.inverted != s.inverted {
+		return ErrInvalidCheckpoint
+	}
+
+	s.n, s.min, s.max = c.n, c.min, c.max
+	return nil
+}
@@ -0,0 +1,73 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestGrowSetRollback(t *testing.T) {
+	g := growSetOf(10, 2, 5)
+	c := g.Checkpoint()
+
+	g.Add(9)
+	g.Add(0)
+	g.Add(5)
+
+	err := g.Rollback(c)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, g.Contains, g.Size(), 10, 2, 5)
+
+	min, _ := g.Min()
+	max, _ := g.Max()
+	assert(t, min == 2 && max == 5, "extremes should be rolled back")
+
+	g.Pop()
+	err = g.Rollback(c)
+	assert(t, err == ErrInvalidCheckpoint, "error should be ErrInvalidCheckpoint")
+	assertMembers(t, g.Contains, g.Size(), 10, 2)
+}
+
+func TestGrowSetBacktracking(t *testing.T) {
+	// Count the subsets of {0, ..., 5} by adding each value in turn
+	// and rolling back.
+	g := NewGrowSet(6)
+	count := 0
+
+	var search func(v int)
+	search = func(v int) {
+		if v == 6 {
+			count++
+			return
+		}
+
+		c := g.Checkpoint()
+		search(v + 1)
+		g.Add(v)
+		search(v + 1)
+		g.Rollback(c)
+		assert(t, !g.Contains(v), "rollback should remove %v", v)
+	}
+
+	search(0)
+	assert(t, count == 64, "there should be 64 subsets, found %v", count)
+}
+
+func TestShrinkSetRollback(t *testing.T) {
+	s := NewShrinkSet(6)
+	s.Remove(1)
+	c := s.Checkpoint()
+
+	s.Remove(0)
+	s.Remove(5)
+	s.Pop()
+
+	err := s.Rollback(c)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, s.Contains, s.Size(), 6, 0, 2, 3, 4, 5)
+
+	max, _ := s.Max()
+	assert(t, max == 5, "max should be 5")
+
+	s.Add(1)
+	err = s.Rollback(c)
+	assert(t, err == ErrInvalidCheckpoint, "error should be ErrInvalidCheckpoint")
+}
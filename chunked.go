@@ -0,0 +1,390 @@
+package intset
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+	"unsafe"
+)
+
+// A ChunkedSet can hold any int, however large or small, and uses
+// memory in proportion to its members rather than to the range of
+// values it can hold. It is modelled on Roaring bitmaps: values are
+// split by their upper bits into chunks of 65536, and each chunk stores
+// the lower 16 bits of its members as a sorted array while it has at
+// most 4096 of them, and as a bitmap once it has more. Optimize can
+// further compress chunks that consist of long runs of consecutive
+// values.
+//
+// Unlike the other set types, Contains, Add, and Remove take
+// O(log c + log k) time, where c is the number of chunks and k is the
+// number of members in the chunk involved, and Values allocates a new
+// slice on every call.
+type ChunkedSet struct {
+	n      int
+	chunks []chunk
+}
+
+// A chunk holds the members of a ChunkedSet sharing their upper bits.
+// At most one of array, bitmap, and runs is non-nil; if none is, the
+// chunk is an empty array.
+type chunk struct {
+	key int
+	n   int
+
+	// The lower 16 bits of each member, in increasing order.
+	array []uint16
+
+	// One bit per possible member.
+	bitmap []uint64
+
+	// Pairs of the first value of each run of consecutive members and
+	// the number of members in it minus one, in increasing order.
+	runs []uint16
+}
+
+// Create a new, empty ChunkedSet.
+func NewChunkedSet() *ChunkedSet {
+	return &ChunkedSet{}
+}
+
+// Splits value into the key of its chunk and its lower 16 bits.
+func splitChunked(value int) (int, uint16) {
+	return value >> 16, uint16(value)
+}
+
+// Returns the index of the chunk with the given key, and whether it
+// exists. If it does not, the index is where it would be inserted.
+func (c *ChunkedSet) find(key int) (int, bool) {
+	return slices.BinarySearchFunc(c.chunks, key, func(ch chunk, key int) int {
+		return ch.key - key
+	})
+}
+
+// Returns true if value is a member of the set.
+func (c *ChunkedSet) Contains(value int) bool {
+	key, low := splitChunked(value)
+	i, ok := c.find(key)
+	return ok && c.chunks[i].contains(low)
+}
+
+// Removes all elements from the set, releasing its storage.
+func (c *ChunkedSet) Clear() {
+	c.n = 0
+	c.chunks = nil
+}
+
+// Returns the size of the set.
+func (c *ChunkedSet) Size() int {
+	return c.n
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// Every int can be stored in a ChunkedSet, so the error is always nil;
+// it is returned for consistency with the other set types.
+func (c *ChunkedSet) Add(value int) error {
+	key, low := splitChunked(value)
+	i, ok := c.find(key)
+	if !ok {
+		c.chunks = slices.Insert(c.chunks, i, chunk{key: key})
+	}
+
+	if c.chunks[i].add(low) {
+		c.n++
+	}
+
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (c *ChunkedSet) Remove(item int) {
+	key, low := splitChunked(item)
+	i, ok := c.find(key)
+	if !ok || !c.chunks[i].remove(low) {
+		return
+	}
+
+	c.n--
+	if c.chunks[i].n == 0 {
+		c.chunks = slices.Delete(c.chunks, i, i+1)
+	}
+}
+
+// Remove and return the largest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (c *ChunkedSet) Pop() (int, error) {
+	if c.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	last := &c.chunks[len(c.chunks)-1]
+	value := last.key<<16 | int(last.popMax())
+	c.n--
+	if last.n == 0 {
+		c.chunks = c.chunks[:len(c.chunks)-1]
+	}
+
+	return value, nil
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (c *ChunkedSet) Values() []int {
+	result := make([]int, 0, c.n)
+	for v := range c.All() {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members of the set, in increasing order.
+// The set should not be modified during iteration.
+func (c *ChunkedSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := range c.chunks {
+			if !c.chunks[i].each(c.chunks[i].key<<16, yield) {
+				return
+			}
+		}
+	}
+}
+
+// Compresses every chunk that would be smaller stored as runs of
+// consecutive values, as Roaring's run containers do. A compressed chunk
+// is expanded again the next time a value is added to or removed from
+// it. This takes O(k) time, where k is the size of the set.
+func (c *ChunkedSet) Optimize() {
+	for i := range c.chunks {
+		c.chunks[i].optimize()
+	}
+}
+
+// Returns the number of bytes of memory used by the set, including the
+// storage of each of its chunks.
+func (c *ChunkedSet) MemoryUsage() int {
+	result := int(unsafe.Sizeof(*c)) + int(unsafe.Sizeof(chunk{}))*cap(c.chunks)
+	for i := range c.chunks {
+		ch := &c.chunks[i]
+		result += 2*cap(ch.array) + 8*cap(ch.bitmap) + 2*cap(ch.runs)
+	}
+
+	return result
+}
+
+// Returns the index of the run that would contain low, or -1 if low
+// precedes every run.
+func (ch *chunk) findRun(low uint16) int {
+	lo, hi := 0, len(ch.runs)/2
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if ch.runs[2*mid] <= low {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo - 1
+}
+
+// Calls yield with base | v for each member v of the chunk, in
+// increasing order, returning false if yield asked to stop.
+func (ch *chunk) each(base int, yield func(int) bool) bool {
+	switch {
+	case ch.bitmap != nil:
+		for w, word := range ch.bitmap {
+			for word != 0 {
+				if !yield(base | (64*w + bits.TrailingZeros64(word))) {
+					return false
+				}
+
+				word &= word - 1
+			}
+		}
+	case ch.runs != nil:
+		for r := 0; r < len(ch.runs); r += 2 {
+			start, length := int(ch.runs[r]), int(ch.runs[r+1])
+			for v := start; v <= start+length; v++ {
+				if !yield(base | v) {
+					return false
+				}
+			}
+		}
+	default:
+		for _, v := range ch.array {
+			if !yield(base | int(v)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Returns true if low is a member of the chunk.
+func (ch *chunk) contains(low uint16) bool {
+	switch {
+	case ch.bitmap != nil:
+		return ch.bitmap[low/64]&(1<<(low%64)) != 0
+	case ch.runs != nil:
+		r := ch.findRun(low)
+		return r >= 0 && int(low) <= int(ch.runs[2*r])+int(ch.runs[2*r+1])
+	default:
+		_, found := slices.BinarySearch(ch.array, low)
+		return found
+	}
+}
+
+// Adds low to the chunk, returning true if it was not already a member.
+func (ch *chunk) add(low uint16) bool {
+	if ch.contains(low) {
+		return false
+	}
+
+	ch.expand()
+	if ch.bitmap != nil {
+		ch.bitmap[low/64] |= 1 << (low % 64)
+	} else {
+		i, _ := slices.BinarySearch(ch.array, low)
+		ch.array = slices.Insert(ch.array, i, low)
+	}
+
+	ch.n++
+	if ch.bitmap == nil && ch.n > roaringArrayLimit {
+		ch.toBitmap()
+	}
+
+	return true
+}
+
+// Removes low from the chunk, returning true if it was a member.
+func (ch *chunk) remove(low uint16) bool {
+	if !ch.contains(low) {
+		return false
+	}
+
+	ch.expand()
+	if ch.bitmap != nil {
+		ch.bitmap[low/64] &^= 1 << (low % 64)
+	} else {
+		i, _ := slices.BinarySearch(ch.array, low)
+		ch.array = slices.Delete(ch.array, i, i+1)
+	}
+
+	ch.n--
+	if ch.bitmap != nil && ch.n <= roaringArrayLimit {
+		ch.toArray()
+	}
+
+	return true
+}
+
+// Removes and returns the largest member of a non-empty chunk.
+func (ch *chunk) popMax() uint16 {
+	var low uint16
+	switch {
+	case ch.bitmap != nil:
+		w := len(ch.bitmap) - 1
+		for ch.bitmap[w] == 0 {
+			w--
+		}
+
+		bit := 63 - bits.LeadingZeros64(ch.bitmap[w])
+		ch.bitmap[w] &^= 1 << bit
+		low = uint16(64*w + bit)
+	case ch.runs != nil:
+		last := len(ch.runs) - 2
+		low = ch.runs[last] + ch.runs[last+1]
+		if ch.runs[last+1] == 0 {
+			ch.runs = ch.runs[:last]
+		} else {
+			ch.runs[last+1]--
+		}
+	default:
+		low = ch.array[len(ch.array)-1]
+		ch.array = ch.array[:len(ch.array)-1]
+	}
+
+	ch.n--
+	if ch.bitmap != nil && ch.n <= roaringArrayLimit {
+		ch.toArray()
+	}
+
+	return low
+}
+
+// Converts a chunk stored as runs back to an array or bitmap.
+func (ch *chunk) expand() {
+	if ch.runs == nil {
+		return
+	}
+
+	runs := ch.runs
+	ch.runs = nil
+	ch.array = make([]uint16, 0, ch.n)
+	for r := 0; r < len(runs); r += 2 {
+		for v := int(runs[r]); v <= int(runs[r])+int(runs[r+1]); v++ {
+			ch.array = append(ch.array, uint16(v))
+		}
+	}
+
+	if ch.n > roaringArrayLimit {
+		ch.toBitmap()
+	}
+}
+
+// Converts a chunk stored as an array to a bitmap.
+func (ch *chunk) toBitmap() {
+	ch.bitmap = make([]uint64, roaringBitmapSizeBytes/8)
+	for _, v := range ch.array {
+		ch.bitmap[v/64] |= 1 << (v % 64)
+	}
+
+	ch.array = nil
+}
+
+// Converts a chunk stored as a bitmap to an array.
+func (ch *chunk) toArray() {
+	ch.array = make([]uint16, 0, ch.n)
+	for w, word := range ch.bitmap {
+		for word != 0 {
+			ch.array = append(ch.array, uint16(64*w+bits.TrailingZeros64(word)))
+			word &= word - 1
+		}
+	}
+
+	ch.bitmap = nil
+}
+
+// Stores the chunk as runs if that would take less memory than its
+// current representation.
+func (ch *chunk) optimize() {
+	if ch.runs != nil {
+		return
+	}
+
+	var runs []uint16
+	var previous int
+	ch.each(0, func(v int) bool {
+		if len(runs) > 0 && v == previous+1 {
+			runs[len(runs)-1]++
+		} else {
+			runs = append(runs, uint16(v), 0)
+		}
+
+		previous = v
+		return true
+	})
+
+	size := 2 * len(ch.array)
+	if ch.bitmap != nil {
+		size = roaringBitmapSizeBytes
+	}
+
+	if 2*len(runs) < size {
+		ch.runs = slices.Clip(runs)
+		ch.array, ch.bitmap = nil, nil
+	}
+}
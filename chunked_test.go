@@ -0,0 +1,101 @@
+package intset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestChunkedSet(t *testing.T) {
+	set := NewChunkedSet()
+
+	for _, v := range []int{5, -3, 1 << 40, 70000, 5} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, set.Size() == 4, "set size should be 4")
+	for _, v := range []int{5, -3, 1 << 40, 70000} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{0, 4, -2, 1<<40 + 1, 70000 - 65536} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	values := set.Values()
+	assert(t, slices.Equal(values, []int{-3, 5, 70000, 1 << 40}), "values are wrong: %v", values)
+
+	set.Remove(70000)
+	set.Remove(70000)
+	v, err := set.Pop()
+	assert(t, err == nil && v == 1<<40, "pop should return the largest member")
+	assert(t, set.Size() == 2, "set size should be 2")
+
+	set.Clear()
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestChunkedSetContainers(t *testing.T) {
+	set := NewChunkedSet()
+	for v := 0; v < 10000; v += 2 {
+		set.Add(v)
+	}
+
+	assert(t, set.chunks[0].bitmap != nil, "a large chunk should be a bitmap")
+	for v := 0; v < 2000; v += 2 {
+		set.Remove(v)
+	}
+	assert(t, set.chunks[0].array != nil, "a smaller chunk should be an array")
+
+	set.Clear()
+	for v := 100; v < 20000; v++ {
+		set.Add(v)
+	}
+
+	before := set.MemoryUsage()
+	set.Optimize()
+	assert(t, set.chunks[0].runs != nil, "a run should be compressed")
+	assert(t, set.MemoryUsage() < before, "optimizing should save memory")
+	assert(t, set.Contains(100) && set.Contains(19999) && !set.Contains(99), "runs should keep members")
+
+	set.Add(5)
+	assert(t, set.chunks[0].runs == nil && set.Contains(150), "adding should expand the chunk")
+}
+
+func TestChunkedSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set := NewChunkedSet()
+	members := map[int]bool{}
+
+	for i := 0; i < 50000; i++ {
+		v := r.Intn(3<<16) - 1<<16
+		switch r.Intn(8) {
+		case 0:
+			set.Remove(v)
+			delete(members, v)
+		case 1:
+			if got, err := set.Pop(); err == nil {
+				delete(members, got)
+			}
+		case 2:
+			if i%1000 == 0 {
+				set.Optimize()
+			}
+		default:
+			set.Add(v)
+			members[v] = true
+		}
+
+		if i%5000 == 0 || i == 49999 {
+			assert(t, set.Size() == len(members), "set size should be %v", len(members))
+			for _, v := range set.Values() {
+				assert(t, members[v], "set should not contain %v", v)
+			}
+			for v := range members {
+				assert(t, set.Contains(v), "set should contain %v", v)
+			}
+		}
+	}
+}
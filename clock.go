@@ -0,0 +1,139 @@
+package intset
+
+import (
+	"iter"
+)
+
+// A ClockSet is a fixed-capacity set that chooses members to evict with
+// the clock, or second-chance, algorithm used for page and cache
+// replacement. Each member has a reference bit, set by Touch, and a
+// clock hand sweeps over the members: EvictOne clears the bit of each
+// referenced member it passes, and removes the first one whose bit was
+// already clear. It supports the following operations in O(1) time,
+// amortized in the case of EvictOne:
+//
+//   Add(n)     - Add n to the set, with its reference bit clear.
+//   Remove(n)  - Remove n from the set.
+//   Touch(n)   - Set the reference bit of n.
+//   EvictOne() - Remove and return the next unreferenced member.
+type ClockSet struct {
+	members    *SparseSet
+	referenced []uint64
+	hand       int
+}
+
+// Allocate a new, empty ClockSet able to store the integers less than
+// capacity.
+func NewClockSet(capacity int) *ClockSet {
+	return &ClockSet{
+		members:    NewSparseSet(capacity),
+		referenced: make([]uint64, (capacity+63)/64),
+	}
+}
+
+// Returns true if value is a member of the set.
+func (c *ClockSet) Contains(value int) bool {
+	return c.members.Contains(value)
+}
+
+// Removes all elements from the set.
+func (c *ClockSet) Clear() {
+	c.members.Clear()
+	c.hand = 0
+}
+
+// Returns the size of the set.
+func (c *ClockSet) Size() int {
+	return c.members.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (c *ClockSet) Capacity() int {
+	return c.members.Capacity()
+}
+
+// Adds value to the set with its reference bit clear. Adding a value that
+// is already a member is not an error, and leaves its bit unchanged.
+// If a value is less than zero or too large to be stored in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (c *ClockSet) Add(value int) error {
+	added, err := c.members.AddReported(value)
+	if added {
+		c.referenced[value/64] &^= 1 << (value % 64)
+	}
+
+	return err
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (c *ClockSet) Remove(item int) {
+	c.members.Remove(item)
+}
+
+// Sets the reference bit of value, so that the clock hand passes over
+// it once before it can be evicted. Returns true if value is a member of
+// the set; if it is not, nothing happens.
+func (c *ClockSet) Touch(value int) bool {
+	if !c.members.Contains(value) {
+		return false
+	}
+
+	c.referenced[value/64] |= 1 << (value % 64)
+	return true
+}
+
+// Returns true if value is a member of the set with its reference bit
+// set.
+func (c *ClockSet) Referenced(value int) bool {
+	return c.members.Contains(value) && c.referenced[value/64]&(1<<(value%64)) != 0
+}
+
+// Advances the clock hand to the next unreferenced member, clearing the
+// reference bit of each member it passes, then removes and returns it.
+// This takes amortized O(1) time, and at most O(k), where k is the size
+// of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (c *ClockSet) EvictOne() (int, error) {
+	s := c.members
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	for {
+		if c.hand >= s.n {
+			c.hand = 0
+		}
+
+		value := s.dense[c.hand]
+		word, bit := value/64, uint64(1)<<(value%64)
+		if c.referenced[word]&bit == 0 {
+			// Removing value moves the last member into its place, so
+			// the hand is left where it is to visit that member next.
+			s.Remove(value)
+			return value, nil
+		}
+
+		c.referenced[word] &^= bit
+		c.hand++
+	}
+}
+
+// Remove and return the next unreferenced member, as EvictOne does.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (c *ClockSet) Pop() (int, error) {
+	return c.EvictOne()
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (c *ClockSet) Values() []int {
+	return c.members.Values()
+}
+
+// Returns an iterator over the members of the set.
+// As with SparseSet, the member being visited may safely be removed
+// during iteration.
+func (c *ClockSet) All() iter.Seq[int] {
+	return c.members.All()
+}
@@ -0,0 +1,55 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClockSet(t *testing.T) {
+	c := NewClockSet(100)
+	assert(t, c.Capacity() == 100 && c.Size() == 0, "set should start empty")
+
+	for _, v := range []int{10, 20, 30, 40} {
+		err := c.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	err := c.Add(100)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	assert(t, c.Touch(10) && c.Touch(20), "10 and 20 should be members")
+	assert(t, !c.Touch(50), "50 should not be a member")
+	assert(t, c.Referenced(10) && !c.Referenced(30), "only touched members are referenced")
+
+	value, err := c.EvictOne()
+	assert(t, err == nil && value == 30, "30 should be evicted first, got %v", value)
+	assert(t, !c.Referenced(10) && !c.Referenced(20), "the hand should clear bits it passes")
+
+	c.Touch(40)
+	value, _ = c.EvictOne()
+	assert(t, value == 10, "referenced 40 should be skipped, got %v", value)
+	assertMembers(t, c.Contains, c.Size(), 100, 20, 40)
+
+	c.Clear()
+	_, err = c.EvictOne()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestClockSetAllReferenced(t *testing.T) {
+	c := NewClockSet(10)
+	for v := 0; v < 10; v++ {
+		c.Add(v)
+		c.Touch(v)
+	}
+
+	evicted := map[int]bool{}
+	for c.Size() > 0 {
+		value, err := c.EvictOne()
+		assert(t, err == nil && !evicted[value], "bad eviction %v", value)
+		evicted[value] = true
+	}
+
+	assert(t, len(evicted) == 10, "every member should be evicted")
+	c.Add(3)
+	assert(t, !c.Referenced(3), "re-added member should start unreferenced")
+}
@@ -0,0 +1,125 @@
+package intset
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Returns a deep copy of s, sharing no storage with it.
+func (s *set) clone() *set {
+	result := *s
+	result.sparse = slices.Clone(s.sparse)
+	result.dense = slices.Clone(s.dense)
+	result.shared = false
+	return &result
+}
+
+// Makes s a copy of other, reusing the storage of s if it has the same
+// capacity as other and is not shared with a fork. If all is false, only the members of other are
+// copied, which is enough for types that tolerate stale entries in
+// their arrays.
+func (s *set) copyFrom(other *set, all bool) {
+	sparse, dense := s.sparse, s.dense
+	if s.shared || len(sparse) != len(other.sparse) {
+		sparse = make([]int, len(other.sparse), len(other.sparse))
+		dense = make([]int, len(other.dense), len(other.dense))
+	}
+
+	mods := s.mods + uint(s.n) + 1
+	*s = *other
+	s.sparse, s.dense = sparse, dense
+	s.shared = false
+	s.mods = mods
+
+	if all {
+		copy(s.sparse, other.sparse)
+		copy(s.dense, other.dense)
+		return
+	}
+
+	copy(s.dense, other.dense[:other.n])
+	for i, v := range s.dense[:other.n] {
+		s.sparse[v-s.offset] = i
+	}
+}
+
+// Returns a deep copy of the set, sharing no storage with it.
+// This takes O(n) time, where n == capacity.
+func (g *GrowSet) Clone() *GrowSet {
+	return (*GrowSet)((*set)(g).clone())
+}
+
+// Makes the set a copy of other. If the set has the same capacity as
+// other its storage is reused, and this takes O(|other|) time without
+// allocating.
+func (g *GrowSet) CopyFrom(other *GrowSet) {
+	(*set)(g).copyFrom((*set)(other), false)
+}
+
+// Returns a deep copy of the set, sharing no storage with it.
+// This takes O(n) time, where n == capacity.
+func (s *ShrinkSet) Clone() *ShrinkSet {
+	return (*ShrinkSet)((*set)(s).clone())
+}
+
+// Makes the set a copy of other. If the set has the same capacity as
+// other its storage is reused, and no allocation takes place.
+// This takes O(n) time, where n == capacity.
+func (s *ShrinkSet) CopyFrom(other *ShrinkSet) {
+	(*set)(s).copyFrom((*set)(other), true)
+}
+
+// Returns a deep copy of the set, sharing no storage with it.
+// This takes O(n) time, where n == capacity.
+func (s *SparseSet) Clone() *SparseSet {
+	return (*SparseSet)((*set)(s).clone())
+}
+
+// Makes the set a copy of other. If the set has the same capacity as
+// other its storage is reused, and this takes O(|other|) time without
+// allocating.
+func (s *SparseSet) CopyFrom(other *SparseSet) {
+	(*set)(s).copyFrom((*set)(other), false)
+}
+
+// Returns a deep copy of the set, sharing no storage with it.
+// Writers are blocked while the copy is made.
+func (a *AtomicGrowSet) Clone() *AtomicGrowSet {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return &AtomicGrowSet{
+		n:      atomic.LoadInt64(&a.n),
+		sparse: cloneAtomic(a.sparse),
+		dense:  cloneAtomic(a.dense),
+	}
+}
+
+// Returns a copy of values, loading each entry atomically.
+func cloneAtomic(values []int64) []int64 {
+	result := make([]int64, len(values))
+	for i := range values {
+		result[i] = atomic.LoadInt64(&values[i])
+	}
+
+	return result
+}
+
+// Returns a deep copy of the set, sharing no storage with it.
+// Each shard is copied atomically, but the set as a whole is not.
+func (s *ShardedSet) Clone() *ShardedSet {
+	result := &ShardedSet{
+		capacity: s.capacity,
+		shards:   make([]shard, len(s.shards)),
+	}
+
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.Lock()
+		result.shards[i].set = sh.set.Clone()
+		result.size += int64(sh.set.Size())
+		sh.Unlock()
+	}
+
+	return result
+}
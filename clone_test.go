@@ -0,0 +1,81 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestGrowSetCloneAndCopyFrom(t *testing.T) {
+	a := growSetOf(10, 1, 4, 7)
+	b := a.Clone()
+	b.Add(2)
+	a.Add(9)
+
+	assertMembers(t, a.Contains, a.Size(), 10, 1, 4, 7, 9)
+	assertMembers(t, b.Contains, b.Size(), 10, 1, 2, 4, 7)
+
+	c := growSetOf(10, 0, 3)
+	c.CopyFrom(a)
+	assertMembers(t, c.Contains, c.Size(), 10, 1, 4, 7, 9)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		c.CopyFrom(b)
+	})
+	assert(t, allocs == 0, "CopyFrom with matching capacity should not allocate")
+	assertMembers(t, c.Contains, c.Size(), 10, 1, 2, 4, 7)
+
+	d := NewGrowSet(3)
+	d.CopyFrom(a)
+	assertMembers(t, d.Contains, d.Size(), 10, 1, 4, 7, 9)
+	max, _ := d.Max()
+	assert(t, max == 9, "max should be 9, is %v", max)
+}
+
+func TestShrinkSetCloneAndCopyFrom(t *testing.T) {
+	a := NewShrinkSet(6)
+	a.Remove(2)
+	b := a.Clone()
+	b.Remove(4)
+	a.Remove(0)
+
+	assertMembers(t, a.Contains, a.Size(), 6, 1, 3, 4, 5)
+	assertMembers(t, b.Contains, b.Size(), 6, 0, 1, 3, 5)
+
+	c := NewShrinkSet(6)
+	c.CopyFrom(b)
+	assertMembers(t, c.Contains, c.Size(), 6, 0, 1, 3, 5)
+
+	c.Refill()
+	assertMembers(t, c.Contains, c.Size(), 6, 0, 1, 2, 3, 4, 5)
+	assertMembers(t, b.Contains, b.Size(), 6, 0, 1, 3, 5)
+}
+
+func TestSparseSetCloneAndCopyFrom(t *testing.T) {
+	a := NewSparseSet(10)
+	a.Add(3)
+	a.Add(8)
+	b := a.Clone()
+	b.Remove(3)
+
+	assertMembers(t, a.Contains, a.Size(), 10, 3, 8)
+	assertMembers(t, b.Contains, b.Size(), 10, 8)
+
+	b.CopyFrom(a)
+	assertMembers(t, b.Contains, b.Size(), 10, 3, 8)
+}
+
+func TestConcurrentSetClone(t *testing.T) {
+	a := NewAtomicGrowSet(10)
+	a.Add(5)
+	b := a.Clone()
+	b.Add(6)
+	assertMembers(t, a.Contains, a.Size(), 10, 5)
+	assertMembers(t, b.Contains, b.Size(), 10, 5, 6)
+
+	s := NewShardedSet(10, 3)
+	s.Add(1)
+	s.Add(2)
+	c := s.Clone()
+	c.Remove(1)
+	assertMembers(t, s.Contains, s.Size(), 10, 1, 2)
+	assertMembers(t, c.Contains, c.Size(), 10, 2)
+}
@@ -0,0 +1,368 @@
+package intset
+
+import (
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// CompactIndex is the set of types that compact sets can use for their
+// internal arrays. A set using uint16 can hold the integers less than
+// 65536; one using uint32, those less than 4294967296.
+type CompactIndex interface {
+	~uint16 | ~uint32
+}
+
+// Returns the largest capacity a compact set using T can have, which is
+// at most math.MaxInt, since on 32-bit platforms an int cannot count
+// every value of uint32.
+func maxCompactCapacity[T CompactIndex]() int {
+	if limit := uint64(^T(0)); limit < math.MaxInt {
+		return int(limit) + 1
+	}
+
+	return math.MaxInt
+}
+
+// Allocates the arrays for a compact set of the given capacity.
+// It panics if capacity is negative or too large for T.
+func makeCompact[T CompactIndex](capacity int) ([]T, []T) {
+	validateCapacity(capacity)
+	if capacity > maxCompactCapacity[T]() {
+		panic("intset: capacity too large for index type")
+	}
+
+	return make([]T, capacity, capacity), make([]T, capacity, capacity)
+}
+
+// Returns a newly allocated slice holding the members in dense.
+func compactValues[T CompactIndex](dense []T) []int {
+	result := make([]int, len(dense))
+	for i, v := range dense {
+		result[i] = int(v)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members in dense[:*n], visiting them
+// from the end backwards like the All methods of the other sets.
+func compactAll[T CompactIndex](n *int, dense []T) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := *n - 1; i >= 0; i-- {
+			if i < *n && !yield(int(dense[i])) {
+				return
+			}
+		}
+	}
+}
+
+// A CompactGrowSet is a GrowSet that stores its arrays as uint16 or
+// uint32 rather than int, using a half or a quarter of the memory on
+// 64-bit platforms. It supports the same operations with the same time
+// complexity, but Values allocates a new slice on every call, so All
+// should be preferred for iteration.
+type CompactGrowSet[T CompactIndex] struct {
+	n      int
+	sparse []T
+	dense  []T
+}
+
+// Allocate a new CompactGrowSet.
+// The resulting set will be able to store the integers less than
+// capacity. It panics if capacity is larger than the number of
+// distinct values of T.
+func NewCompactGrowSet[T CompactIndex](capacity int) *CompactGrowSet[T] {
+	sparse, dense := makeCompact[T](capacity)
+	return &CompactGrowSet[T]{sparse: sparse, dense: dense}
+}
+
+// Returns true if value is a member of the set.
+func (g *CompactGrowSet[T]) Contains(value int) bool {
+	if value < 0 || value >= len(g.sparse) {
+		return false
+	}
+
+	index := int(g.sparse[value])
+	return index < g.n && int(g.dense[index]) == value
+}
+
+// Removes all elements from the set.
+func (g *CompactGrowSet[T]) Clear() {
+	g.n = 0
+}
+
+// Returns the size of the set.
+func (g *CompactGrowSet[T]) Size() int {
+	return g.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (g *CompactGrowSet[T]) Capacity() int {
+	return len(g.sparse)
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (g *CompactGrowSet[T]) Add(value int) error {
+	if value < 0 || value >= len(g.sparse) {
+		return outOfRange(value, 0, len(g.sparse))
+	}
+
+	if !g.Contains(value) {
+		g.dense[g.n] = T(value)
+		g.sparse[value] = T(g.n)
+		g.n++
+	}
+
+	return nil
+}
+
+// Remove and return the most recently added member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *CompactGrowSet[T]) Pop() (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	g.n--
+	return int(g.dense[g.n]), nil
+}
+
+// Returns a newly allocated slice containing the members of the set.
+func (g *CompactGrowSet[T]) Values() []int {
+	return compactValues(g.dense[:g.n])
+}
+
+// Returns an iterator over the members of the set.
+// As with GrowSet, the set may safely be added to during iteration;
+// members added during iteration are not visited.
+// Iteration does not allocate.
+func (g *CompactGrowSet[T]) All() iter.Seq[int] {
+	return compactAll(&g.n, g.dense)
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays.
+func (g *CompactGrowSet[T]) MemoryUsage() int {
+	return int(unsafe.Sizeof(*g)) + int(unsafe.Sizeof(T(0)))*(cap(g.sparse)+cap(g.dense))
+}
+
+// A CompactShrinkSet is a ShrinkSet that stores its arrays as uint16 or
+// uint32 rather than int. Unlike ShrinkSet it is not initialized
+// lazily, so construction takes O(n) time, where n == capacity.
+// Values allocates a new slice on every call, so All should be
+// preferred for iteration.
+type CompactShrinkSet[T CompactIndex] struct {
+	n      int
+	sparse []T
+	dense  []T
+}
+
+// Create a new CompactShrinkSet storing the numbers up to, but not
+// including, capacity. It panics if capacity is larger than the number
+// of distinct values of T.
+func NewCompactShrinkSet[T CompactIndex](capacity int) *CompactShrinkSet[T] {
+	sparse, dense := makeCompact[T](capacity)
+	for i := range sparse {
+		sparse[i] = T(i)
+		dense[i] = T(i)
+	}
+
+	return &CompactShrinkSet[T]{n: capacity, sparse: sparse, dense: dense}
+}
+
+// Returns true if value is in the set.
+func (s *CompactShrinkSet[T]) Contains(value int) bool {
+	return value >= 0 && value < len(s.sparse) && int(s.sparse[value]) < s.n
+}
+
+// Resets the set to its original state in O(1) time.
+func (s *CompactShrinkSet[T]) Refill() {
+	s.n = len(s.dense)
+}
+
+// Returns the number of elements in the set.
+func (s *CompactShrinkSet[T]) Size() int {
+	return s.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *CompactShrinkSet[T]) Capacity() int {
+	return len(s.sparse)
+}
+
+// Moves value to index i of the dense array, moving the value there to
+// the old position of value.
+func (s *CompactShrinkSet[T]) moveTo(value int, i int) {
+	from := s.sparse[value]
+	other := s.dense[i]
+
+	s.dense[i], s.dense[from] = T(value), other
+	s.sparse[value], s.sparse[other] = T(i), from
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *CompactShrinkSet[T]) Remove(item int) {
+	if s.Contains(item) {
+		s.moveTo(item, s.n-1)
+		s.n--
+	}
+}
+
+// Remove and return the member at the front of the set's internal
+// ordering.
+// If the set is empty, the result will be zero and
+// error will be ErrEmptySet.
+func (s *CompactShrinkSet[T]) Pop() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	removed := int(s.dense[0])
+	s.Remove(removed)
+	return removed, nil
+}
+
+// Adds a previously removed value back to the set.
+// Adding a value that is already in the set is not an error.
+// If a value is less than zero or too large to have been in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (s *CompactShrinkSet[T]) Add(value int) error {
+	if value < 0 || value >= len(s.sparse) {
+		return outOfRange(value, 0, len(s.sparse))
+	}
+
+	if !s.Contains(value) {
+		s.moveTo(value, s.n)
+		s.n++
+	}
+
+	return nil
+}
+
+// Returns a newly allocated slice containing the members of the set.
+func (s *CompactShrinkSet[T]) Values() []int {
+	return compactValues(s.dense[:s.n])
+}
+
+// Returns an iterator over the members of the set.
+// As with ShrinkSet, the member being visited may safely be removed
+// during iteration.
+// Iteration does not allocate.
+func (s *CompactShrinkSet[T]) All() iter.Seq[int] {
+	return compactAll(&s.n, s.dense)
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays.
+func (s *CompactShrinkSet[T]) MemoryUsage() int {
+	return int(unsafe.Sizeof(*s)) + int(unsafe.Sizeof(T(0)))*(cap(s.sparse)+cap(s.dense))
+}
+
+// A CompactSparseSet is a SparseSet that stores its arrays as uint16 or
+// uint32 rather than int. It supports the same operations with the
+// same time complexity, but Values allocates a new slice on every
+// call, so All should be preferred for iteration.
+type CompactSparseSet[T CompactIndex] struct {
+	n      int
+	sparse []T
+	dense  []T
+}
+
+// Allocate a new CompactSparseSet.
+// The resulting set will be able to store the integers less than
+// capacity. It panics if capacity is larger than the number of
+// distinct values of T.
+func NewCompactSparseSet[T CompactIndex](capacity int) *CompactSparseSet[T] {
+	sparse, dense := makeCompact[T](capacity)
+	return &CompactSparseSet[T]{sparse: sparse, dense: dense}
+}
+
+// Returns true if value is a member of the set.
+func (s *CompactSparseSet[T]) Contains(value int) bool {
+	if value < 0 || value >= len(s.sparse) {
+		return false
+	}
+
+	index := int(s.sparse[value])
+	return index < s.n && int(s.dense[index]) == value
+}
+
+// Removes all elements from the set.
+func (s *CompactSparseSet[T]) Clear() {
+	s.n = 0
+}
+
+// Returns the size of the set.
+func (s *CompactSparseSet[T]) Size() int {
+	return s.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *CompactSparseSet[T]) Capacity() int {
+	return len(s.sparse)
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (s *CompactSparseSet[T]) Add(value int) error {
+	if value < 0 || value >= len(s.sparse) {
+		return outOfRange(value, 0, len(s.sparse))
+	}
+
+	if !s.Contains(value) {
+		s.dense[s.n] = T(value)
+		s.sparse[value] = T(s.n)
+		s.n++
+	}
+
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *CompactSparseSet[T]) Remove(item int) {
+	if s.Contains(item) {
+		itemIndex := s.sparse[item]
+		lastItem := s.dense[s.n-1]
+
+		s.dense[itemIndex] = lastItem
+		s.sparse[lastItem] = itemIndex
+		s.n--
+	}
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *CompactSparseSet[T]) Pop() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	s.n--
+	return int(s.dense[s.n]), nil
+}
+
+// Returns a newly allocated slice containing the members of the set.
+func (s *CompactSparseSet[T]) Values() []int {
+	return compactValues(s.dense[:s.n])
+}
+
+// Returns an iterator over the members of the set.
+// As with SparseSet, the member being visited may safely be removed
+// during iteration, and members added during iteration are not visited.
+// Iteration does not allocate.
+func (s *CompactSparseSet[T]) All() iter.Seq[int] {
+	return compactAll(&s.n, s.dense)
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays.
+func (s *CompactSparseSet[T]) MemoryUsage() int {
+	return int(unsafe.Sizeof(*s)) + int(unsafe.Sizeof(T(0)))*(cap(s.sparse)+cap(s.dense))
+}
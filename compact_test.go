@@ -0,0 +1,122 @@
+package intset
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCompactGrowSet(t *testing.T) {
+	set := NewCompactGrowSet[uint16](6)
+
+	set.Add(1)
+	set.Add(3)
+	set.Add(4)
+	set.Add(3)
+
+	assert(t, set.Size() == 3, "set size should be 3")
+	assert(t, set.Capacity() == 6, "set capacity should be 6")
+
+	for _, v := range []int{-1, 0, 2, 5, 6} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	values := set.Values()
+	slices.Sort(values)
+	assert(t, slices.Equal(values, []int{1, 3, 4}), "values should be [1 3 4], got %v", values)
+
+	popped, err := set.Pop()
+	assert(t, err == nil && popped == 4, "pop should return the last added member")
+
+	err = set.Add(6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	set.Clear()
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestCompactShrinkSet(t *testing.T) {
+	set := NewCompactShrinkSet[uint32](5)
+	assert(t, set.Size() == 5, "set size should be 5")
+
+	set.Remove(1)
+	set.Remove(3)
+	set.Remove(3)
+
+	for _, v := range []int{0, 2, 4} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{-1, 1, 3, 5} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	set.Add(1)
+	assert(t, set.Contains(1) && set.Size() == 4, "set should contain 1 again")
+
+	seen := 0
+	for set.Size() > 0 {
+		v, err := set.Pop()
+		assert(t, err == nil, "error is not nil: %v", err)
+		seen += v
+	}
+	assert(t, seen == 7, "popped values should sum to 7")
+
+	set.Refill()
+	values := set.Values()
+	slices.Sort(values)
+	assert(t, slices.Equal(values, []int{0, 1, 2, 3, 4}), "refilled values are wrong: %v", values)
+}
+
+func TestCompactSparseSet(t *testing.T) {
+	set := NewCompactSparseSet[uint16](1 << 16)
+
+	for _, v := range []int{0, 7, 65535} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	set.Remove(7)
+	set.Remove(8)
+
+	assert(t, set.Size() == 2, "set size should be 2")
+	assert(t, set.Contains(65535) && set.Contains(0), "set should contain 0 and 65535")
+	assert(t, !set.Contains(7), "set should not contain 7")
+
+	var all []int
+	for v := range set.All() {
+		set.Remove(v)
+		all = append(all, v)
+	}
+	assert(t, len(all) == 2 && set.Size() == 0, "All should visit every member")
+
+	err := set.Add(1 << 16)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestCompactCapacityTooLarge(t *testing.T) {
+	defer func() {
+		assert(t, recover() != nil, "constructor should panic")
+	}()
+
+	NewCompactSparseSet[uint16](1<<16 + 1)
+}
+
+func TestMaxCompactCapacity(t *testing.T) {
+	assert(t, maxCompactCapacity[uint16]() == 1<<16, "uint16 capacity should be 65536")
+	want := uint64(math.MaxUint32) + 1
+	if want > math.MaxInt {
+		want = math.MaxInt
+	}
+	assert(t, uint64(maxCompactCapacity[uint32]()) == want, "uint32 capacity should be %v", want)
+}
+
+func TestCompactMemoryUsage(t *testing.T) {
+	full := NewSparseSet(1000).MemoryUsage()
+	half := NewCompactSparseSet[uint32](1000).MemoryUsage()
+	quarter := NewCompactSparseSet[uint16](1000).MemoryUsage()
+
+	assert(t, half < full && quarter < half, "compact sets should use less memory")
+}
@@ -0,0 +1,212 @@
+package intset
+
+import (
+	"math/bits"
+	"unsafe"
+)
+
+// The number of fingerprints each bucket of a CuckooFilter holds.
+const cuckooBucketSize = 4
+
+// The number of fingerprints a CuckooFilter relocates looking for room
+// before it gives up and reports that it is full.
+const cuckooMaxKicks = 500
+
+// A CuckooFilter records integers in a fixed amount of memory, two bytes
+// per value it is able to hold, and answers whether a value may have
+// been added. Unlike a Bloom filter it supports removal, which makes it
+// suited to long-running deduplication windows where entries age out.
+// It supports the following operations with the associated time
+// complexity:
+//
+//   Add(n)        - Record integer n, in amortized O(1) time.
+//   Remove(n)     - Forget a previously added n, in O(1) time.
+//   MayContain(n) - Check if n may have been added, in O(1) time.
+//
+// MayContain never returns false for a value that has been added and
+// not removed, but returns true for a value that has not with a
+// probability of about 1 in 8000. Each value is stored as a 16-bit
+// fingerprint in one of two buckets, so adding a value twice records it
+// twice, and only values that have been added should be removed:
+// removing any other value may forget a different one that shares its
+// fingerprint.
+type CuckooFilter struct {
+	n       int
+	buckets [][cuckooBucketSize]uint16
+	victim  uint16
+	spill   int
+	state   uint64
+}
+
+// Allocate a new, empty CuckooFilter able to hold at least capacity
+// values. The number of buckets is rounded up to a power of two, and
+// leaves room to spare so that the filter rarely fills before it holds
+// capacity values.
+func NewCuckooFilter(capacity int) *CuckooFilter {
+	validateCapacity(capacity)
+	n := (capacity*10/9 + cuckooBucketSize - 1) / cuckooBucketSize
+	return &CuckooFilter{
+		buckets: make([][cuckooBucketSize]uint16, 1<<bits.Len(uint(max(n, 1)-1))),
+		state:   0x9e3779b97f4a7c15,
+	}
+}
+
+// Returns the fingerprint of value and the first bucket it may be in.
+func (c *CuckooFilter) locate(value int) (uint16, int) {
+	h := mix(uint64(value))
+	fingerprint := uint16(h >> 48)
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+
+	return fingerprint, int(h) & (len(c.buckets) - 1)
+}
+
+// Returns the other bucket a fingerprint in bucket i may be in.
+func (c *CuckooFilter) alternate(i int, fingerprint uint16) int {
+	return (i ^ int(mix(uint64(fingerprint)))) & (len(c.buckets) - 1)
+}
+
+// Stores fingerprint in a free slot of bucket i, returning false if
+// there is none.
+func (c *CuckooFilter) insert(i int, fingerprint uint16) bool {
+	for j, f := range c.buckets[i] {
+		if f == 0 {
+			c.buckets[i][j] = fingerprint
+			return true
+		}
+	}
+
+	return false
+}
+
+// Removes one copy of fingerprint from bucket i, returning false if it
+// is not there.
+func (c *CuckooFilter) delete(i int, fingerprint uint16) bool {
+	for j, f := range c.buckets[i] {
+		if f == fingerprint {
+			c.buckets[i][j] = 0
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns true if bucket i holds fingerprint.
+func (c *CuckooFilter) holds(i int, fingerprint uint16) bool {
+	for _, f := range c.buckets[i] {
+		if f == fingerprint {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns a pseudo-random slot index, using a xorshift generator so the
+// filter does not allocate or share a source of randomness.
+func (c *CuckooFilter) slot() int {
+	c.state ^= c.state << 13
+	c.state ^= c.state >> 7
+	c.state ^= c.state << 17
+	return int(c.state % cuckooBucketSize)
+}
+
+// Records value in the filter. Adding the same value multiple times
+// records it multiple times.
+// If the filter is full, ErrFilterFull is returned and value is not
+// recorded, otherwise nil.
+func (c *CuckooFilter) Add(value int) error {
+	if c.victim != 0 {
+		return ErrFilterFull
+	}
+
+	fingerprint, i := c.locate(value)
+	if c.insert(i, fingerprint) {
+		c.n++
+		return nil
+	}
+
+	i = c.alternate(i, fingerprint)
+	if c.insert(i, fingerprint) {
+		c.n++
+		return nil
+	}
+
+	// Both buckets are full, so evict fingerprints to their alternate
+	// buckets until one finds room. If none does, the last one evicted
+	// is held aside as the victim, so nothing that was added is lost.
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		j := c.slot()
+		fingerprint, c.buckets[i][j] = c.buckets[i][j], fingerprint
+		i = c.alternate(i, fingerprint)
+		if c.insert(i, fingerprint) {
+			c.n++
+			return nil
+		}
+	}
+
+	c.victim, c.spill = fingerprint, i
+	c.n++
+	return nil
+}
+
+// Returns true if value may have been added to the filter and not
+// removed, and false if it certainly has not.
+func (c *CuckooFilter) MayContain(value int) bool {
+	fingerprint, i := c.locate(value)
+	j := c.alternate(i, fingerprint)
+	if c.victim == fingerprint && (c.spill == i || c.spill == j) {
+		return true
+	}
+
+	return c.holds(i, fingerprint) || c.holds(j, fingerprint)
+}
+
+// Forgets one copy of value, returning true if a copy was found. Only
+// values that have been added should be removed.
+func (c *CuckooFilter) Remove(value int) bool {
+	fingerprint, i := c.locate(value)
+	j := c.alternate(i, fingerprint)
+	if c.victim == fingerprint && (c.spill == i || c.spill == j) {
+		c.victim = 0
+	} else if !c.delete(i, fingerprint) && !c.delete(j, fingerprint) {
+		return false
+	}
+
+	c.n--
+	if c.victim != 0 {
+		// Removal may have freed a slot for the victim in one of its
+		// buckets.
+		if c.insert(c.spill, c.victim) || c.insert(c.alternate(c.spill, c.victim), c.victim) {
+			c.victim = 0
+		}
+	}
+
+	return true
+}
+
+// Forgets every value in the filter.
+// This takes O(n) time, where n == capacity.
+func (c *CuckooFilter) Clear() {
+	clear(c.buckets)
+	c.n, c.victim = 0, 0
+}
+
+// Returns the number of values recorded in the filter.
+func (c *CuckooFilter) Size() int {
+	return c.n
+}
+
+// Returns the number of fingerprints the filter has room for. Values
+// can usually be added until the filter is about 95% full.
+func (c *CuckooFilter) Capacity() int {
+	return cuckooBucketSize * len(c.buckets)
+}
+
+// Returns the number of bytes of memory used by the filter, including
+// its buckets.
+func (c *CuckooFilter) MemoryUsage() int {
+	return int(unsafe.Sizeof(*c)) + int(unsafe.Sizeof(c.buckets[0]))*cap(c.buckets)
+}
@@ -0,0 +1,73 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	c := NewCuckooFilter(1000)
+	assert(t, c.Capacity() >= 1000, "capacity should be at least 1000, is %v", c.Capacity())
+
+	for v := 0; v < 1000; v++ {
+		err := c.Add(v * 7)
+		assert(t, err == nil, "error adding %v: %v", v*7, err)
+	}
+
+	assert(t, c.Size() == 1000, "size should be 1000, is %v", c.Size())
+	for v := 0; v < 1000; v++ {
+		assert(t, c.MayContain(v*7), "%v should be reported as present", v*7)
+	}
+
+	falsePositives := 0
+	for v := 1_000_000; v < 1_100_000; v++ {
+		if c.MayContain(v) {
+			falsePositives++
+		}
+	}
+
+	assert(t, falsePositives < 100, "too many false positives: %v", falsePositives)
+
+	for v := 0; v < 500; v++ {
+		assert(t, c.Remove(v*7), "%v should have been removed", v*7)
+	}
+
+	assert(t, c.Size() == 500, "size should be 500, is %v", c.Size())
+	for v := 500; v < 1000; v++ {
+		assert(t, c.MayContain(v*7), "%v should still be reported as present", v*7)
+	}
+
+	c.Add(7000)
+	c.Add(7000)
+	assert(t, c.Remove(7000) && c.MayContain(7000), "a value added twice should survive one removal")
+	assert(t, c.Remove(7000), "the second copy should be removed")
+
+	c.Clear()
+	assert(t, c.Size() == 0 && !c.MayContain(3500), "filter should be empty after Clear")
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	c := NewCuckooFilter(100)
+	added := []int{}
+	for v := 0; ; v++ {
+		if err := c.Add(v); err != nil {
+			assert(t, err == ErrFilterFull, "error should be ErrFilterFull, is %v", err)
+			break
+		}
+
+		added = append(added, v)
+	}
+
+	assert(t, len(added) >= 100, "filter should hold at least 100 values, held %v", len(added))
+	for _, v := range added {
+		assert(t, c.MayContain(v), "%v should be reported as present", v)
+	}
+
+	for _, v := range added[:10] {
+		c.Remove(v)
+	}
+
+	assert(t, c.Add(-1) == nil, "removal should make room")
+	for _, v := range added[10:] {
+		assert(t, c.MayContain(v), "%v should be reported as present", v)
+	}
+}
@@ -0,0 +1,79 @@
+package intset
+
+// Appends the members of a that are not members of b to onlyA, and the
+// members of b that are not members of a to onlyB, returning the
+// extended slices. Either slice may be nil, and reusing them across
+// calls makes this allocation-free. This suits reconciliation loops,
+// where a is the desired state and b the actual one, or vice versa.
+// This takes O(|a| + |b|) time.
+func Diff(a, b IntSet, onlyA, onlyB []int) ([]int, []int) {
+	return appendMissing(onlyA, a.Values(), b.Contains), appendMissing(onlyB, b.Values(), a.Contains)
+}
+
+// Appends each of values for which contains returns false to dst.
+func appendMissing(dst []int, values []int, contains func(int) bool) []int {
+	for _, v := range values {
+		if !contains(v) {
+			dst = append(dst, v)
+		}
+	}
+
+	return dst
+}
+
+// Appends the members of the set that are not members of other to
+// onlyG, and the members of other that are not members of the set to
+// onlyOther, returning the extended slices. Either slice may be nil.
+// This takes O(|g| + |other|) time.
+func (g *GrowSet) Diff(other *GrowSet, onlyG, onlyOther []int) ([]int, []int) {
+	return appendMissing(onlyG, g.Values(), other.Contains), appendMissing(onlyOther, other.Values(), g.Contains)
+}
+
+// Appends the members of the set that are not members of other to
+// onlyS, and the members of other that are not members of the set to
+// onlyOther, returning the extended slices. Either slice may be nil.
+// This takes O(|s| + |other|) time.
+func (s *ShrinkSet) Diff(other *ShrinkSet, onlyS, onlyOther []int) ([]int, []int) {
+	return appendMissing(onlyS, s.Values(), other.Contains), appendMissing(onlyOther, other.Values(), s.Contains)
+}
+
+// Appends the members of the set that are not members of other to
+// onlyS, and the members of other that are not members of the set to
+// onlyOther, returning the extended slices. Either slice may be nil.
+// This takes O(|s| + |other|) time.
+func (s *SparseSet) Diff(other *SparseSet, onlyS, onlyOther []int) ([]int, []int) {
+	return appendMissing(onlyS, s.Values(), other.Contains), appendMissing(onlyOther, other.Values(), s.Contains)
+}
+
+// Adds the members of a that are not members of b to onlyA, and the
+// members of b that are not members of a to onlyB, so that the
+// differences can be collected in sets of the caller's choosing.
+// If a value cannot be added to its destination, the first error from
+// Add is returned after the rest have been added, otherwise nil.
+// This takes O(|a| + |b|) time.
+func DiffInto(a, b IntSet, onlyA, onlyB Adder) error {
+	errA := addMissing(onlyA, a.Values(), b.Contains)
+	errB := addMissing(onlyB, b.Values(), a.Contains)
+	if errA != nil {
+		return errA
+	}
+
+	return errB
+}
+
+// Adds each of values for which contains returns false to dst, returning
+// the first error from Add after adding the rest.
+func addMissing(dst Adder, values []int, contains func(int) bool) error {
+	var err error
+	for _, v := range values {
+		if contains(v) {
+			continue
+		}
+
+		if e := dst.Add(v); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,41 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	desired := NewSparseSet(10)
+	desired.AddAll(1, 2, 3, 7)
+	actual := NewSparseSet(10)
+	actual.AddAll(2, 3, 5, 9)
+
+	add, remove := desired.Diff(actual, nil, nil)
+	slices.Sort(add)
+	slices.Sort(remove)
+	assert(t, slices.Equal(add, []int{1, 7}), "only in desired should be [1 7], is %v", add)
+	assert(t, slices.Equal(remove, []int{5, 9}), "only in actual should be [5 9], is %v", remove)
+
+	add, remove = Diff(desired, actual, add[:0], remove[:0])
+	slices.Sort(add)
+	slices.Sort(remove)
+	assert(t, slices.Equal(add, []int{1, 7}) && slices.Equal(remove, []int{5, 9}), "Diff should agree with the method")
+
+	a, b := growSetOf(10, 4), growSetOf(10, 4)
+	onlyA, onlyB := a.Diff(b, nil, nil)
+	assert(t, len(onlyA) == 0 && len(onlyB) == 0, "equal sets should have no differences")
+
+	s, o := NewShrinkSet(5), NewShrinkSet(5)
+	s.Remove(0)
+	o.Remove(4)
+	onlyS, onlyO := s.Diff(o, nil, nil)
+	assert(t, slices.Equal(onlyS, []int{4}) && slices.Equal(onlyO, []int{0}), "differences are %v and %v", onlyS, onlyO)
+
+	intoA, intoB := NewGrowSet(10), NewGrowSet(6)
+	err := DiffInto(desired, actual, intoA, intoB)
+	assertMembers(t, intoA.Contains, intoA.Size(), 10, 1, 7)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, intoB.Contains, intoB.Size(), 6, 5)
+}
@@ -0,0 +1,115 @@
+package intset
+
+// DisjointSets partitions the integers less than its capacity into
+// disjoint sets, initially one for each integer, which can be merged.
+// It supports the following operations in amortized nearly O(1) time,
+// using union by rank and path compression:
+//
+//   Union(a, b)   - Merge the sets containing a and b.
+//   Find(x)       - Return the representative of the set containing x.
+//   SameSet(a, b) - Check if a and b are in the same set.
+//
+// Like the other types in this package, DisjointSets does not allocate
+// memory after construction.
+type DisjointSets struct {
+	parent []int
+	rank   []int
+	count  int
+}
+
+// Allocate a new DisjointSets over the integers less than capacity,
+// each in a set of its own.
+// This takes O(n) time, where n == capacity.
+func NewDisjointSets(capacity int) *DisjointSets {
+	validateCapacity(capacity)
+	result := &DisjointSets{
+		parent: make([]int, capacity, capacity),
+		rank:   make([]int, capacity, capacity),
+	}
+
+	result.Reset()
+	return result
+}
+
+// Puts every integer back into a set of its own.
+// This takes O(n) time, where n == capacity.
+func (d *DisjointSets) Reset() {
+	for i := range d.parent {
+		d.parent[i] = i
+		d.rank[i] = 0
+	}
+
+	d.count = len(d.parent)
+}
+
+// Returns the number of integers partitioned.
+func (d *DisjointSets) Capacity() int {
+	return len(d.parent)
+}
+
+// Returns the number of disjoint sets.
+func (d *DisjointSets) Count() int {
+	return d.count
+}
+
+// Returns the representative of the set containing x. Two integers are
+// in the same set exactly when they have the same representative.
+// If x is less than zero or too large to be in the partition, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (d *DisjointSets) Find(x int) (int, error) {
+	if x < 0 || x >= len(d.parent) {
+		return 0, outOfRange(x, 0, len(d.parent))
+	}
+
+	return d.find(x), nil
+}
+
+// Returns the representative of the set containing x, halving the path
+// to it as it goes.
+func (d *DisjointSets) find(x int) int {
+	for d.parent[x] != x {
+		d.parent[x] = d.parent[d.parent[x]]
+		x = d.parent[x]
+	}
+
+	return x
+}
+
+// Merges the sets containing a and b. Merging two integers already in
+// the same set is not an error.
+// If either is less than zero or too large to be in the partition,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (d *DisjointSets) Union(a, b int) error {
+	for _, x := range [...]int{a, b} {
+		if x < 0 || x >= len(d.parent) {
+			return outOfRange(x, 0, len(d.parent))
+		}
+	}
+
+	a, b = d.find(a), d.find(b)
+	if a == b {
+		return nil
+	}
+
+	if d.rank[a] < d.rank[b] {
+		a, b = b, a
+	}
+
+	d.parent[b] = a
+	if d.rank[a] == d.rank[b] {
+		d.rank[a]++
+	}
+
+	d.count--
+	return nil
+}
+
+// Returns true if a and b are in the same set. Integers outside the
+// partition are in no set.
+func (d *DisjointSets) SameSet(a, b int) bool {
+	if a < 0 || a >= len(d.parent) || b < 0 || b >= len(d.parent) {
+		return false
+	}
+
+	return d.find(a) == d.find(b)
+}
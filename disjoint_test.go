@@ -0,0 +1,50 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDisjointSets(t *testing.T) {
+	d := NewDisjointSets(10)
+	assert(t, d.Count() == 10, "there should be 10 sets")
+	assert(t, d.Capacity() == 10, "capacity should be 10")
+
+	for _, pair := range [][2]int{{0, 1}, {2, 3}, {1, 3}, {5, 6}, {0, 2}} {
+		err := d.Union(pair[0], pair[1])
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, d.Count() == 6, "there should be 6 sets, found %v", d.Count())
+	assert(t, d.SameSet(0, 3) && d.SameSet(1, 2), "0 to 3 should be in one set")
+	assert(t, d.SameSet(5, 6), "5 and 6 should be in one set")
+	assert(t, !d.SameSet(3, 5) && !d.SameSet(4, 7), "sets should be disjoint")
+	assert(t, !d.SameSet(-1, -1) && !d.SameSet(10, 10), "out of range values are in no set")
+
+	r0, err := d.Find(0)
+	assert(t, err == nil, "error is not nil: %v", err)
+	r3, _ := d.Find(3)
+	assert(t, r0 == r3, "0 and 3 should have the same representative")
+
+	_, err = d.Find(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	err = d.Union(1, 10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	d.Reset()
+	assert(t, d.Count() == 10 && !d.SameSet(0, 1), "reset should separate every integer")
+}
+
+func TestDisjointSetsChain(t *testing.T) {
+	const capacity = 1000
+	d := NewDisjointSets(capacity)
+	for v := 1; v < capacity; v++ {
+		d.Union(v-1, v)
+	}
+
+	assert(t, d.Count() == 1, "there should be one set")
+	for v := 0; v < capacity; v++ {
+		assert(t, d.SameSet(0, v), "%v should be in the same set as 0", v)
+	}
+}
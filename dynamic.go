@@ -0,0 +1,91 @@
+package intset
+
+import (
+	"iter"
+	"math"
+)
+
+// A DynamicSet behaves like a GrowSet, but rather than rejecting values
+// too large for it, it transparently reallocates its storage to make
+// room for them. The capacity at least doubles each time, so Add takes
+// amortized O(1) time. Unlike the other set types, a DynamicSet may
+// allocate memory after construction.
+type DynamicSet set
+
+// Allocate a new DynamicSet with room for the integers less than
+// capacity. The set grows as needed, so capacity is only a hint.
+func NewDynamicSet(capacity int) *DynamicSet {
+	return (*DynamicSet)(NewGrowSet(capacity))
+}
+
+// Returns true if value is a member of the set.
+func (d *DynamicSet) Contains(value int) bool {
+	return (*GrowSet)(d).Contains(value)
+}
+
+// Removes all elements from the set. The storage of the set is kept.
+func (d *DynamicSet) Clear() {
+	d.mods += uint(d.n)
+	d.n = 0
+}
+
+// Returns the size of the set.
+func (d *DynamicSet) Size() int {
+	return d.n
+}
+
+// Returns the number of distinct values the set is able to store
+// before it next grows.
+func (d *DynamicSet) Capacity() int {
+	return len(d.sparse)
+}
+
+// Adds value to the set, growing it if necessary. Adding the same value
+// multiple times is not an error.
+// If a value is less than zero, ErrValueOutOfRange is returned,
+// otherwise nil.
+func (d *DynamicSet) Add(value int) error {
+	if value < 0 {
+		return outOfRange(value, 0, math.MaxInt)
+	}
+
+	if value >= len(d.sparse) {
+		(*set)(d).grow(max(2*len(d.sparse), value+1))
+	}
+
+	return (*GrowSet)(d).Add(value)
+}
+
+// Adds value to the set, like Add, and returns true if it was not
+// already a member.
+// If value is less than zero, the result will be false and error will
+// be ErrValueOutOfRange.
+func (d *DynamicSet) AddReported(value int) (bool, error) {
+	if value < 0 {
+		return false, outOfRange(value, 0, math.MaxInt)
+	}
+
+	if value >= len(d.sparse) {
+		(*set)(d).grow(max(2*len(d.sparse), value+1))
+	}
+
+	return (*GrowSet)(d).AddReported(value)
+}
+
+// Remove and return the most recently added member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (d *DynamicSet) Pop() (int, error) {
+	return (*GrowSet)(d).Pop()
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified, and is not updated if the set
+// grows.
+func (d *DynamicSet) Values() []int {
+	return d.dense[:d.n]
+}
+
+// Returns an iterator over the members of the set. See GrowSet.All.
+func (d *DynamicSet) All() iter.Seq[int] {
+	return (*GrowSet)(d).All()
+}
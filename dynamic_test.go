@@ -0,0 +1,51 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDynamicSet(t *testing.T) {
+	set := NewDynamicSet(0)
+
+	for _, v := range []int{3, 0, 100, 3, 17} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assertMembers(t, set.Contains, set.Size(), 200, 0, 3, 17, 100)
+	assert(t, !set.Contains(-1) && !set.Contains(1000), "set should not contain out of range values")
+
+	err := set.Add(-1)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	popped, err := set.Pop()
+	assert(t, err == nil && popped == 17, "should have popped 17, popped %v", popped)
+
+	set.Clear()
+	assert(t, set.Size() == 0, "set size should be 0")
+
+	count := 0
+	set.Add(5)
+	for v := range set.All() {
+		assert(t, v == 5, "unexpected value %v", v)
+		count++
+	}
+
+	assert(t, count == 1, "should have visited 1 member")
+}
+
+func TestDynamicSetAmortizedGrowth(t *testing.T) {
+	set := NewDynamicSet(1)
+	grows := 0
+	for v := 0; v < 10000; v++ {
+		capacity := len(set.sparse)
+		set.Add(v)
+		if len(set.sparse) != capacity {
+			grows++
+		}
+	}
+
+	assert(t, set.Size() == 10000, "set size should be 10000")
+	assert(t, grows <= 14, "set grew %v times", grows)
+}
@@ -0,0 +1,117 @@
+package intset
+
+// Removes every member of the set for which keep returns false, in a
+// single O(k) pass, where k is the size of the set, without allocating.
+// The members kept stay in the same order.
+func (g *GrowSet) RetainIf(keep func(int) bool) {
+	g.retain(keep)
+}
+
+// Removes every member of the set for which keep returns false, in a
+// single O(k) pass, where k is the size of the set, without allocating.
+// Members are visited from the end of the dense array backwards, and
+// each one removed is swapped with the last member, which has already
+// been visited.
+func (s *ShrinkSet) RetainIf(keep func(int) bool) {
+	for i := s.n - 1; i >= 0; i-- {
+		if v := s.at(i); !keep(v) {
+			s.Remove(v)
+		}
+	}
+}
+
+// Removes every member of the set for which keep returns false, in a
+// single O(k) pass, where k is the size of the set, without allocating.
+// The members kept stay in the same order.
+func (s *SparseSet) RetainIf(keep func(int) bool) {
+	(*GrowSet)(s).retain(keep)
+}
+
+// Removes every member of the set for which extract returns true,
+// appending them to dst and returning the extended slice. dst may be
+// nil. This is a single O(k) pass, where k is the size of the set.
+func (g *GrowSet) ExtractIf(extract func(int) bool, dst []int) []int {
+	g.retain(extractInto(extract, &dst))
+	return dst
+}
+
+// Removes every member of the set for which extract returns true,
+// appending them to dst and returning the extended slice. dst may be
+// nil. This is a single O(k) pass, where k is the size of the set.
+func (s *ShrinkSet) ExtractIf(extract func(int) bool, dst []int) []int {
+	s.RetainIf(extractInto(extract, &dst))
+	return dst
+}
+
+// Removes every member of the set for which extract returns true,
+// appending them to dst and returning the extended slice. dst may be
+// nil. This is a single O(k) pass, where k is the size of the set.
+func (s *SparseSet) ExtractIf(extract func(int) bool, dst []int) []int {
+	(*GrowSet)(s).retain(extractInto(extract, &dst))
+	return dst
+}
+
+// Returns a function for RetainIf that appends the values for which
+// extract returns true to *dst, and keeps the rest.
+func extractInto(extract func(int) bool, dst *[]int) func(int) bool {
+	return func(v int) bool {
+		if extract(v) {
+			*dst = append(*dst, v)
+			return false
+		}
+
+		return true
+	}
+}
+
+// Adds each member of the set for which pred returns true to yes, and
+// each of the others to no, in a single O(k) pass, where k is the size
+// of the set. The set itself is unchanged. Either destination may be
+// nil, in which case the members that would go to it are discarded.
+// Members that a destination cannot store are skipped, and reported
+// together in a *RejectedValuesError; otherwise the result is nil.
+func (g *GrowSet) Partition(pred func(int) bool, yes, no Adder) error {
+	return partition(g.Values(), pred, yes, no)
+}
+
+// Adds each member of the set for which pred returns true to yes, and
+// each of the others to no, in a single O(k) pass, where k is the size
+// of the set. The set itself is unchanged. Either destination may be
+// nil, in which case the members that would go to it are discarded.
+// Members that a destination cannot store are skipped, and reported
+// together in a *RejectedValuesError; otherwise the result is nil.
+func (s *ShrinkSet) Partition(pred func(int) bool, yes, no Adder) error {
+	return partition(s.Values(), pred, yes, no)
+}
+
+// Adds each member of the set for which pred returns true to yes, and
+// each of the others to no, in a single O(k) pass, where k is the size
+// of the set. The set itself is unchanged. Either destination may be
+// nil, in which case the members that would go to it are discarded.
+// Members that a destination cannot store are skipped, and reported
+// together in a *RejectedValuesError; otherwise the result is nil.
+func (s *SparseSet) Partition(pred func(int) bool, yes, no Adder) error {
+	return partition(s.Values(), pred, yes, no)
+}
+
+// Adds each of values for which pred returns true to yes, and each of
+// the others to no, skipping nil destinations.
+func partition(values []int, pred func(int) bool, yes, no Adder) error {
+	var rejected []int
+	for _, v := range values {
+		dst := no
+		if pred(v) {
+			dst = yes
+		}
+
+		if dst != nil && dst.Add(v) != nil {
+			rejected = append(rejected, v)
+		}
+	}
+
+	if rejected != nil {
+		return &RejectedValuesError{Values: rejected}
+	}
+
+	return nil
+}
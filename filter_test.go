@@ -0,0 +1,78 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func isEven(v int) bool {
+	return v%2 == 0
+}
+
+func TestRetainIf(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3, 4, 8)
+	g.RetainIf(isEven)
+	assertMembers(t, g.Contains, g.Size(), 10, 2, 4, 8)
+
+	max, _ := g.Max()
+	assert(t, max == 8, "max should be 8")
+
+	s := NewShrinkSet(10)
+	s.RetainIf(isEven)
+	assertMembers(t, s.Contains, s.Size(), 10, 0, 2, 4, 6, 8)
+
+	p := NewSparseSet(10)
+	p.AddAll(9, 7, 6, 0)
+	p.RetainIf(isEven)
+	assertMembers(t, p.Contains, p.Size(), 10, 0, 6)
+
+	p.RetainIf(func(int) bool { return false })
+	assert(t, p.Size() == 0, "set should be empty")
+}
+
+func TestExtractIf(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3, 4)
+	extracted := g.ExtractIf(isEven, []int{9})
+	assert(t, slices.Equal(extracted, []int{9, 2, 4}), "extracted should be [9 2 4], got %v", extracted)
+	assertMembers(t, g.Contains, g.Size(), 10, 1, 3)
+
+	s := NewShrinkSet(5)
+	extracted = s.ExtractIf(isEven, nil)
+	slices.Sort(extracted)
+	assert(t, slices.Equal(extracted, []int{0, 2, 4}), "extracted should be [0 2 4], got %v", extracted)
+	assertMembers(t, s.Contains, s.Size(), 5, 1, 3)
+
+	p := NewSparseSet(5)
+	p.AddAll(1, 3)
+	extracted = p.ExtractIf(isEven, nil)
+	assert(t, len(extracted) == 0, "nothing should be extracted")
+	assertMembers(t, p.Contains, p.Size(), 5, 1, 3)
+}
+
+func TestPartition(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3, 4, 9)
+	even, odd := NewGrowSet(10), NewSparseSet(5)
+
+	err := g.Partition(isEven, even, odd)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	var rejected *RejectedValuesError
+	assert(t, errors.As(err, &rejected) && slices.Equal(rejected.Values, []int{9}), "9 should be rejected")
+	assertMembers(t, even.Contains, even.Size(), 10, 2, 4)
+	assertMembers(t, odd.Contains, odd.Size(), 5, 1, 3)
+	assertMembers(t, g.Contains, g.Size(), 10, 1, 2, 3, 4, 9)
+
+	s := NewShrinkSet(6)
+	small := NewGrowSet(6)
+	err = s.Partition(func(v int) bool { return v < 3 }, small, nil)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, small.Contains, small.Size(), 6, 0, 1, 2)
+
+	p := NewSparseSet(4)
+	p.AddAll(0, 3)
+	evens := NewGrowSet(4)
+	err = p.Partition(isEven, evens, nil)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, evens.Contains, evens.Size(), 4, 0)
+}
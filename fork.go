@@ -0,0 +1,52 @@
+package intset
+
+import (
+	"slices"
+)
+
+// Returns a copy of s that shares its arrays. Both s and the copy are
+// marked as shared, so each copies the arrays before it first writes to
+// them. Keeping no count of the sharers means neither can know when the
+// other has stopped sharing, but also that forks can be handed to other
+// goroutines without any synchronization.
+func (s *set) fork() *set {
+	s.shared = true
+	result := *s
+	return &result
+}
+
+// Gives s its own copy of its arrays if they may be shared with a fork.
+// Every method that writes to the arrays calls this first.
+func (s *set) own() {
+	if s.shared {
+		s.sparse = slices.Clone(s.sparse)
+		s.dense = slices.Clone(s.dense)
+		s.shared = false
+	}
+}
+
+// Returns a logically independent copy of the set in O(1) time.
+// The copy shares the storage of the set, and each of them copies the
+// storage before it is first modified, taking O(n) time, where
+// n == capacity. This makes it cheap to try out changes to
+// a large set that may be thrown away.
+func (g *GrowSet) Fork() *GrowSet {
+	return (*GrowSet)((*set)(g).fork())
+}
+
+// Returns a logically independent copy of the set in O(1) time.
+// The copy shares the storage of the set, and each of them copies the
+// storage before it is first modified, taking O(n) time, where
+// n == capacity. Since the first call to Values finishes
+// initializing the storage, it counts as a modification.
+func (s *ShrinkSet) Fork() *ShrinkSet {
+	return (*ShrinkSet)((*set)(s).fork())
+}
+
+// Returns a logically independent copy of the set in O(1) time.
+// The copy shares the storage of the set, and each of them copies the
+// storage before it is first modified, taking O(n) time, where
+// n == capacity.
+func (s *SparseSet) Fork() *SparseSet {
+	return (*SparseSet)((*set)(s).fork())
+}
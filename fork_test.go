@@ -0,0 +1,56 @@
+package intset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestGrowSetFork(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3)
+	f := g.Fork()
+
+	f.Add(4)
+	g.Pop()
+	g.Add(5)
+
+	assertMembers(t, g.Contains, g.Size(), 10, 1, 2, 5)
+	assertMembers(t, f.Contains, f.Size(), 10, 1, 2, 3, 4)
+
+	h := f.Fork()
+	h.IntersectWith(growSetOf(10, 1, 4))
+	assertMembers(t, h.Contains, h.Size(), 10, 1, 4)
+	assertMembers(t, f.Contains, f.Size(), 10, 1, 2, 3, 4)
+}
+
+func TestShrinkSetFork(t *testing.T) {
+	s := NewShrinkSet(6)
+	s.Remove(2)
+	f := s.Fork()
+
+	f.Remove(4)
+	assertMembers(t, s.Contains, s.Size(), 6, 0, 1, 3, 4, 5)
+	assertMembers(t, f.Contains, f.Size(), 6, 0, 1, 3, 5)
+
+	values := slices.Clone(s.Values())
+	slices.Sort(values)
+	assert(t, slices.Equal(values, []int{0, 1, 3, 4, 5}), "values are wrong: %v", values)
+
+	f.Add(2)
+	f.PopRandom(rand.New(rand.NewSource(1)))
+	assertMembers(t, s.Contains, s.Size(), 6, 0, 1, 3, 4, 5)
+}
+
+func TestSparseSetFork(t *testing.T) {
+	s := NewSparseSet(10)
+	s.AddAll(1, 5, 7)
+	f := s.Fork()
+
+	f.Remove(5)
+	f.AddRange(8, 10)
+	s.CopyFrom(s.Clone())
+	s.Remove(1)
+
+	assertMembers(t, s.Contains, s.Size(), 10, 5, 7)
+	assertMembers(t, f.Contains, f.Size(), 10, 1, 7, 8, 9)
+}
@@ -0,0 +1,160 @@
+package intset
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Returns the members of s in increasing order, written in range
+// notation, with runs of consecutive members collapsed into ranges:
+//
+//   {0-5, 9, 12-20}
+func format(s IntSet) string {
+	values := slices.Clone(s.Values())
+	slices.Sort(values)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(strconv.Itoa(values[i]))
+		if j > i {
+			b.WriteByte('-')
+			b.WriteString(strconv.Itoa(values[j]))
+		}
+
+		i = j + 1
+	}
+
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Returns the members of the set in range notation, implementing
+// fmt.Stringer.
+func (g *GrowSet) String() string {
+	return format(g)
+}
+
+// Returns the members of the set in range notation, implementing
+// fmt.Stringer.
+func (s *ShrinkSet) String() string {
+	return format(s)
+}
+
+// Returns the members of the set in range notation, implementing
+// fmt.Stringer.
+func (s *SparseSet) String() string {
+	return format(s)
+}
+
+// Returns the members of the set in range notation, implementing
+// fmt.Stringer.
+func (s *ShardedSet) String() string {
+	return format(s)
+}
+
+// Returns the members of the set in range notation, implementing
+// fmt.Stringer.
+func (a *AtomicGrowSet) String() string {
+	return format(a)
+}
+
+// Returns a new GrowSet holding the members written in s, in the range
+// notation produced by String. The braces and spaces are optional, so
+// "{0-5, 9, 12-20}" and "0-5,9,12-20" are equivalent. A minus sign at
+// the start of a number makes it negative, and the range separator is
+// the first '-' after that, so "-3--1" is the range from -3 to -1. The
+// set starts at zero, unless some member is negative, and is just large
+// enough to hold its largest member.
+// If s is not valid range notation, the result will be nil and error
+// will be ErrInvalidSyntax. If the set would need a capacity greater
+// than MaxDecodedCapacity, the result will be nil and error will be
+// ErrValueOutOfRange.
+func Parse(s string) (*GrowSet, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+
+	var ranges [][2]int
+	lo, hi := 0, -1
+	if s != "" {
+		for _, item := range strings.Split(s, ",") {
+			low, high, isRange := cutRange(strings.TrimSpace(item))
+			first, err := parseMember(low)
+			if err != nil {
+				return nil, err
+			}
+
+			last := first
+			if isRange {
+				if last, err = parseMember(high); err != nil || last < first {
+					return nil, ErrInvalidSyntax
+				}
+			}
+
+			ranges = append(ranges, [2]int{first, last})
+			lo, hi = min(lo, first), max(hi, last)
+		}
+	}
+
+	// The difference is computed in uint so that it cannot overflow,
+	// however far apart lo and hi are.
+	if hi >= lo && uint(hi)-uint(lo) >= uint(MaxDecodedCapacity) {
+		return nil, ErrValueOutOfRange
+	}
+
+	result := NewGrowSetRange(lo, hi+1)
+	for _, r := range ranges {
+		// Stop after adding r[1] rather than when v passes it, which it
+		// cannot do if r[1] is the largest int.
+		for v := r[0]; ; v++ {
+			result.Add(v)
+			if v == r[1] {
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Splits s at the range separator, the first '-' that is not the sign
+// of the first number, reporting whether there was one.
+func cutRange(s string) (string, string, bool) {
+	start := 0
+	if strings.HasPrefix(s, "-") {
+		start = 1
+	}
+
+	if i := strings.IndexByte(s[start:], '-'); i >= 0 {
+		return s[:start+i], s[start+i+1:], true
+	}
+
+	return s, "", false
+}
+
+// Parses a single member from s, ignoring surrounding spaces.
+func parseMember(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s[0] == '+' {
+		return 0, ErrInvalidSyntax
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, ErrInvalidSyntax
+	}
+
+	return v, nil
+}
@@ -0,0 +1,96 @@
+package intset
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	set := NewGrowSet(30)
+	for _, v := range []int{12, 0, 1, 2, 3, 4, 5, 9, 13, 14, 15, 16, 17, 18, 19, 20, 22, 23} {
+		set.Add(v)
+	}
+
+	s := fmt.Sprint(set)
+	assert(t, s == "{0-5, 9, 12-20, 22-23}", "formatted as %v", s)
+
+	s = NewGrowSet(3).String()
+	assert(t, s == "{}", "formatted as %v", s)
+
+	shrink := NewShrinkSet(6)
+	shrink.Remove(3)
+	s = shrink.String()
+	assert(t, s == "{0-2, 4-5}", "formatted as %v", s)
+
+	sparse := NewSparseSet(10)
+	sparse.Add(7)
+	s = sparse.String()
+	assert(t, s == "{7}", "formatted as %v", s)
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		input   string
+		members []int
+	}{
+		{"1,3-7,10", []int{1, 3, 4, 5, 6, 7, 10}},
+		{"{0-2, 9}", []int{0, 1, 2, 9}},
+		{" 4 - 5 ,4", []int{4, 5}},
+		{"{}", nil},
+		{"", nil},
+		{"-1", []int{-1}},
+		{"{-3--1, 2}", []int{-3, -2, -1, 2}},
+		{" -2 - 1", []int{-2, -1, 0, 1}},
+	} {
+		set, err := Parse(test.input)
+		assert(t, err == nil, "parsing %q failed: %v", test.input, err)
+		assert(t, set.Size() == len(test.members), "parsing %q should give %v members, not %v", test.input, len(test.members), set.Size())
+		for _, v := range test.members {
+			assert(t, set.Contains(v), "parsing %q should give member %v", test.input, v)
+		}
+	}
+
+	original := growSetOf(30, 0, 1, 2, 5, 9, 10, 29)
+	parsed, err := Parse(original.String())
+	assert(t, err == nil && parsed.Equal(original), "String output should parse back to the same set")
+
+	for _, input := range []string{"1,", "a", "3-1", "1-2-3", "--1", "1--", "-1--3", "+1", "1;2", "{1", "1 2"} {
+		_, err := Parse(input)
+		assert(t, err == ErrInvalidSyntax, "parsing %q should fail, got %v", input, err)
+	}
+}
+
+func TestParseRoundTripNegative(t *testing.T) {
+	original := NewGrowSetRange(-5, 5)
+	for _, v := range []int{-5, -3, -2, -1, 2, 3, 4} {
+		original.Add(v)
+	}
+
+	s := original.String()
+	assert(t, s == "{-5, -3--1, 2-4}", "formatted as %v", s)
+
+	parsed, err := Parse(s)
+	assert(t, err == nil, "parsing %q failed: %v", s, err)
+	assert(t, Equal(parsed, original), "%q should parse back to the same set, not %v", s, parsed)
+}
+
+func TestParseLarge(t *testing.T) {
+	for _, input := range []string{
+		"9223372036854775807",
+		"9223372036854775806-9223372036854775807",
+		"-9223372036854775808",
+		"-9223372036854775808-9223372036854775807",
+		"1000000000",
+	} {
+		_, err := Parse(input)
+		assert(t, errors.Is(err, ErrValueOutOfRange), "parsing %q should fail with ErrValueOutOfRange, got %v", input, err)
+	}
+
+	defer func(saved int) { MaxDecodedCapacity = saved }(MaxDecodedCapacity)
+	MaxDecodedCapacity = 100
+	set, err := Parse("0-3, 99")
+	assert(t, err == nil && set.Size() == 5, "a set at MaxDecodedCapacity should parse, got %v", err)
+	_, err = Parse("-1, 99")
+	assert(t, errors.Is(err, ErrValueOutOfRange), "a set beyond MaxDecodedCapacity should not parse")
+}
@@ -0,0 +1,138 @@
+package intset
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+	"unsafe"
+)
+
+// A FrozenSet is an immutable copy of another set. It has no methods
+// that modify it, so it can be shared between goroutines without
+// locking. Its members are stored in whichever of a sorted slice or a
+// bitmap covering the range from its smallest to its largest member is
+// smaller, so it usually takes far less memory than the set it was
+// made from.
+//
+// Contains takes O(log k) time, where k is the size of the set, when
+// the members are stored as a slice, and O(1) time when they are stored
+// as a bitmap. Values allocates a new slice on every call.
+type FrozenSet struct {
+	n int
+
+	// The members, in increasing order, if the set is stored as a slice.
+	sorted []int
+
+	// If the set is stored as a bitmap, bit i is set if offset + i is a
+	// member.
+	bitmap []uint64
+	offset int
+}
+
+// Returns a FrozenSet holding the members of s.
+// This takes O(k log k) time, where k is the size of s.
+func NewFrozenSet(s IntSet) *FrozenSet {
+	values := slices.Clone(s.Values())
+	slices.Sort(values)
+	if len(values) == 0 {
+		return &FrozenSet{}
+	}
+
+	// The span is computed in uint so that it cannot overflow, however
+	// far apart the members are.
+	lowest, highest := values[0], values[len(values)-1]
+	words := (uint(highest)-uint(lowest))/64 + 1
+	if words >= uint(len(values)) {
+		return &FrozenSet{n: len(values), sorted: slices.Clip(values)}
+	}
+
+	result := &FrozenSet{
+		n:      len(values),
+		bitmap: make([]uint64, words),
+		offset: lowest,
+	}
+
+	for _, v := range values {
+		i := v - lowest
+		result.bitmap[i/64] |= 1 << (i % 64)
+	}
+
+	return result
+}
+
+// Returns an immutable copy of the set.
+// This takes O(k log k) time, where k is the size of the set.
+func (g *GrowSet) Freeze() *FrozenSet {
+	return NewFrozenSet(g)
+}
+
+// Returns an immutable copy of the set.
+// This takes O(k log k) time, where k is the size of the set.
+func (s *ShrinkSet) Freeze() *FrozenSet {
+	return NewFrozenSet(s)
+}
+
+// Returns an immutable copy of the set.
+// This takes O(k log k) time, where k is the size of the set.
+func (s *SparseSet) Freeze() *FrozenSet {
+	return NewFrozenSet(s)
+}
+
+// Returns true if value is a member of the set.
+func (f *FrozenSet) Contains(value int) bool {
+	if f.bitmap == nil {
+		_, found := slices.BinarySearch(f.sorted, value)
+		return found
+	}
+
+	i := value - f.offset
+	return i >= 0 && i < 64*len(f.bitmap) && f.bitmap[i/64]&(1<<(i%64)) != 0
+}
+
+// Returns the size of the set.
+func (f *FrozenSet) Size() int {
+	return f.n
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (f *FrozenSet) Values() []int {
+	if f.bitmap == nil {
+		return slices.Clone(f.sorted)
+	}
+
+	result := make([]int, 0, f.n)
+	for v := range f.All() {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members of the set, in increasing order.
+// Iteration does not allocate.
+func (f *FrozenSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, v := range f.sorted {
+			if !yield(v) {
+				return
+			}
+		}
+
+		for w, word := range f.bitmap {
+			for word != 0 {
+				if !yield(f.offset + 64*w + bits.TrailingZeros64(word)) {
+					return
+				}
+
+				word &= word - 1
+			}
+		}
+	}
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// slice or bitmap.
+func (f *FrozenSet) MemoryUsage() int {
+	return int(unsafe.Sizeof(*f)) + int(unsafe.Sizeof(int(0)))*cap(f.sorted) + 8*cap(f.bitmap)
+}
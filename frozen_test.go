@@ -0,0 +1,76 @@
+package intset
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestFrozenSetSorted(t *testing.T) {
+	g := growSetOf(100000, 5, 99999, 3)
+	f := g.Freeze()
+	g.Add(7)
+
+	assert(t, f.bitmap == nil, "a sparse set should be frozen as a slice")
+	assert(t, f.Size() == 3, "set size should be 3")
+	assert(t, !f.Contains(7), "frozen set should not see later changes")
+	assert(t, f.Contains(99999) && f.Contains(3), "set should contain its members")
+
+	values := f.Values()
+	assert(t, slices.Equal(values, []int{3, 5, 99999}), "values are wrong: %v", values)
+	values[0] = 42
+	assert(t, f.Contains(3), "modifying values should not modify the set")
+}
+
+func TestFrozenSetBitmap(t *testing.T) {
+	s := NewShrinkSetRange(-100, 1000)
+	s.Remove(0)
+	f := s.Freeze()
+
+	assert(t, f.bitmap != nil, "a dense set should be frozen as a bitmap")
+	assert(t, f.Size() == 1099, "set size should be 1099")
+	assert(t, f.MemoryUsage() < s.MemoryUsage()/50, "a bitmap should be small")
+
+	for _, v := range []int{-100, -1, 1, 999} {
+		assert(t, f.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{-101, 0, 1000, 1 << 20} {
+		assert(t, !f.Contains(v), "set should not contain %v", v)
+	}
+
+	values := f.Values()
+	assert(t, len(values) == 1099 && slices.IsSorted(values), "values should be sorted")
+}
+
+func TestFrozenSetExtremes(t *testing.T) {
+	intervals := NewIntervalSet()
+	intervals.Add(math.MinInt)
+	intervals.Add(math.MaxInt)
+
+	f := NewFrozenSet(intervals)
+	assert(t, f.bitmap == nil, "members far apart should be stored as a slice")
+	assert(t, f.Size() == 2 && f.Contains(math.MinInt) && f.Contains(math.MaxInt), "set should hold MinInt and MaxInt")
+	assert(t, !f.Contains(0), "set should not contain 0")
+}
+
+func TestFrozenSetConcurrent(t *testing.T) {
+	f := NewFrozenSet(NewShrinkSet(1000))
+
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range f.All() {
+				if !f.Contains(v) {
+					t.Errorf("set should contain %v", v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert(t, NewFrozenSet(NewGrowSet(10)).Size() == 0, "an empty set should freeze")
+}
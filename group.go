@@ -0,0 +1,122 @@
+package intset
+
+import (
+	"iter"
+)
+
+// A SetGroup owns a number of GroupedSets and can clear all of them at
+// once in O(1) time, however many there are. This suits pools of sets
+// that are all emptied together, such as once per frame.
+//
+// The group keeps a generation number, which ClearAll increments. Each
+// set records the generation in which it was last modified, and treats
+// itself as empty if that is not the current generation.
+type SetGroup struct {
+	generation uint64
+}
+
+// A GroupedSet is a GrowSet belonging to a SetGroup. It supports the
+// same operations with the same time complexity, and is also cleared by
+// the ClearAll method of its group.
+type GroupedSet struct {
+	group      *SetGroup
+	generation uint64
+	set        *GrowSet
+}
+
+// Create a new SetGroup with no sets.
+func NewSetGroup() *SetGroup {
+	return &SetGroup{}
+}
+
+// Allocate a new GroupedSet belonging to the group.
+// The resulting set will be able to store the integers less than
+// capacity.
+func (g *SetGroup) NewSet(capacity int) *GroupedSet {
+	return &GroupedSet{
+		group:      g,
+		generation: g.generation,
+		set:        NewGrowSet(capacity),
+	}
+}
+
+// Removes all elements from every set in the group, in O(1) time.
+func (g *SetGroup) ClearAll() {
+	g.generation++
+}
+
+// Returns true if the set has not been cleared by its group since it
+// was last modified.
+func (s *GroupedSet) current() bool {
+	return s.generation == s.group.generation
+}
+
+// Clears the set if its group has cleared it since it was last
+// modified, so that it can be modified in the current generation.
+func (s *GroupedSet) update() {
+	if !s.current() {
+		s.set.Clear()
+		s.generation = s.group.generation
+	}
+}
+
+// Returns true if value is a member of the set.
+func (s *GroupedSet) Contains(value int) bool {
+	return s.current() && s.set.Contains(value)
+}
+
+// Removes all elements from the set.
+func (s *GroupedSet) Clear() {
+	s.update()
+	s.set.Clear()
+}
+
+// Returns the size of the set.
+func (s *GroupedSet) Size() int {
+	if !s.current() {
+		return 0
+	}
+
+	return s.set.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *GroupedSet) Capacity() int {
+	return s.set.Capacity()
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (s *GroupedSet) Add(value int) error {
+	s.update()
+	return s.set.Add(value)
+}
+
+// Remove and return the most recently added member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *GroupedSet) Pop() (int, error) {
+	s.update()
+	return s.set.Pop()
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (s *GroupedSet) Values() []int {
+	if !s.current() {
+		return nil
+	}
+
+	return s.set.Values()
+}
+
+// Returns an iterator over the members of the set.
+// As with GrowSet, the set may safely be added to during iteration;
+// members added during iteration are not visited.
+func (s *GroupedSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if s.current() {
+			s.set.All()(yield)
+		}
+	}
+}
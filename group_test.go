@@ -0,0 +1,41 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetGroup(t *testing.T) {
+	group := NewSetGroup()
+	a, b := group.NewSet(10), group.NewSet(20)
+
+	a.Add(1)
+	a.Add(2)
+	b.Add(15)
+
+	assert(t, a.Size() == 2 && b.Size() == 1, "sets should have their members")
+	assert(t, a.Capacity() == 10 && b.Capacity() == 20, "sets should have their capacities")
+
+	group.ClearAll()
+	assert(t, a.Size() == 0 && b.Size() == 0, "sets should be empty")
+	assert(t, !a.Contains(1) && !b.Contains(15), "sets should not contain old members")
+	assert(t, len(a.Values()) == 0, "values should be empty")
+
+	for range a.All() {
+		t.Errorf("All should not visit old members")
+	}
+
+	_, err := b.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	a.Add(3)
+	assertMembers(t, a.Contains, a.Size(), 10, 3)
+
+	err = a.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	c := group.NewSet(5)
+	c.Add(4)
+	c.Clear()
+	assert(t, c.Size() == 0 && a.Size() == 1, "Clear should only clear one set")
+}
@@ -0,0 +1,92 @@
+package intset
+
+import (
+	"slices"
+)
+
+// Reallocates the storage of s to hold capacity values, keeping its
+// members. The sparse array is only filled in for the members, so this
+// is only suitable for types that tolerate stale entries in it.
+// This takes O(capacity) time.
+func (s *set) grow(capacity int) {
+	sparse := make([]int, capacity, capacity)
+	dense := make([]int, capacity, capacity)
+	copy(dense, s.dense[:s.n])
+	for i, v := range dense[:s.n] {
+		sparse[v-s.offset] = i
+	}
+
+	s.sparse, s.dense = sparse, dense
+	s.shared = false
+}
+
+// Enlarges the set so that it can store capacity values, keeping its
+// members. If the set can already store that many, nothing happens.
+// This takes O(n) time, where n == capacity, and allocates new storage.
+func (g *GrowSet) Grow(capacity int) {
+	if capacity > len(g.sparse) {
+		(*set)(g).grow(capacity)
+	}
+}
+
+// Enlarges the set so that it can store capacity values, keeping its
+// members. The new values start out as members of the set, and are
+// restored by Refill along with the rest. If the set can already store
+// that many values, nothing happens.
+// This takes O(n) time, where n == capacity, and allocates new storage.
+func (s *ShrinkSet) Grow(capacity int) {
+	old := len(s.sparse)
+	if capacity <= old {
+		return
+	}
+
+	s.materialize()
+	if s.inverted {
+		(*set)(s).own()
+		slices.Reverse(s.dense)
+		s.inverted = false
+	}
+
+	// Members stay at the front of the dense array, followed by the new
+	// values, and then the values that have been removed.
+	dense := make([]int, capacity, capacity)
+	copy(dense, s.dense[:s.n])
+	added := capacity - old
+	for i := 0; i < added; i++ {
+		dense[s.n+i] = s.offset + old + i
+	}
+
+	copy(dense[s.n+added:], s.dense[s.n:])
+
+	sparse := make([]int, capacity, capacity)
+	for i, v := range dense {
+		sparse[v-s.offset] = i
+	}
+
+	if s.n == 0 {
+		s.min = s.offset + old
+	}
+
+	s.sparse, s.dense = sparse, dense
+	s.shared = false
+	s.n += added
+	s.max = s.offset + capacity - 1
+}
+
+// Enlarges the set so that it can store capacity values, keeping its
+// members. If the set can already store that many, nothing happens.
+// This takes O(n) time, where n == capacity, and allocates new storage.
+func (s *SparseSet) Grow(capacity int) {
+	if capacity > len(s.sparse) {
+		(*set)(s).grow(capacity)
+	}
+}
+
+// Enlarges the set so that it can store capacity values without
+// reallocating. If the set can already store that many, nothing
+// happens.
+func (d *DynamicSet) Grow(capacity int) {
+	if capacity > len(d.sparse) {
+		(*set)(d).grow(capacity)
+	}
+}
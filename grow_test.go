@@ -0,0 +1,52 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestGrowSetGrow(t *testing.T) {
+	set := growSetOf(4, 1, 3)
+	set.Grow(10)
+	assertMembers(t, set.Contains, set.Size(), 10, 1, 3)
+
+	err := set.Add(9)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 10, 1, 3, 9)
+
+	set.Grow(5)
+	assert(t, len(set.sparse) == 10, "Grow should never shrink a set")
+}
+
+func TestShrinkSetGrow(t *testing.T) {
+	set := NewShrinkSet(4)
+	set.Remove(1)
+	set.Grow(6)
+	assertMembers(t, set.Contains, set.Size(), 6, 0, 2, 3, 4, 5)
+
+	max, _ := set.Max()
+	assert(t, max == 5, "max should be 5, is %v", max)
+
+	set.Refill()
+	assertMembers(t, set.Contains, set.Size(), 6, 0, 1, 2, 3, 4, 5)
+
+	set = NewShrinkSetRange(-2, 0)
+	set.Remove(-2)
+	set.Remove(-1)
+	set.Grow(3)
+	assertMembers(t, set.Contains, set.Size(), 3, 0)
+	min, _ := set.Min()
+	assert(t, min == 0, "min should be 0, is %v", min)
+}
+
+func TestSparseSetAndDynamicSetGrow(t *testing.T) {
+	set := NewSparseSet(2)
+	set.Add(1)
+	set.Grow(8)
+	set.Add(7)
+	set.Remove(1)
+	assertMembers(t, set.Contains, set.Size(), 8, 7)
+
+	d := NewDynamicSet(0)
+	d.Grow(100)
+	assert(t, len(d.sparse) == 100, "capacity should be 100")
+}
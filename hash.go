@@ -0,0 +1,41 @@
+package intset
+
+// Returns a well-mixed 64-bit value derived from v, using the
+// finalizer from SplitMix64.
+func mix(v uint64) uint64 {
+	v += 0x9e3779b97f4a7c15
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+	return v ^ (v >> 31)
+}
+
+// Returns a hash of the members of s.
+// Members are mixed individually and the results summed, so the hash
+// depends only on which values are in the set, not on the order in
+// which they were added or on the type of set. Sets with different
+// hashes are never equal; sets with the same hash are very likely,
+// but not certain, to be equal.
+// This takes O(|s|) time.
+func Hash(s IntSet) uint64 {
+	var sum uint64
+	for _, v := range s.Values() {
+		sum += mix(uint64(v))
+	}
+
+	return mix(sum ^ uint64(s.Size()))
+}
+
+// Returns a hash of the members of the set. See the Hash function.
+func (g *GrowSet) Hash() uint64 {
+	return Hash(g)
+}
+
+// Returns a hash of the members of the set. See the Hash function.
+func (s *ShrinkSet) Hash() uint64 {
+	return Hash(s)
+}
+
+// Returns a hash of the members of the set. See the Hash function.
+func (s *SparseSet) Hash() uint64 {
+	return Hash(s)
+}
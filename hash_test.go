@@ -0,0 +1,30 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	a := growSetOf(10, 1, 4, 7)
+	b := growSetOf(20, 7, 1, 4)
+	c := growSetOf(10, 1, 4, 8)
+
+	assert(t, a.Hash() == b.Hash(), "hash should not depend on order or capacity")
+	assert(t, a.Hash() != c.Hash(), "different sets should have different hashes")
+	assert(t, NewGrowSet(5).Hash() != growSetOf(5, 0).Hash(), "empty set and {0} should differ")
+
+	s := NewShrinkSet(8)
+	for _, v := range []int{0, 2, 3, 5, 6} {
+		s.Remove(v)
+	}
+
+	assert(t, s.Hash() == a.Hash(), "hash should not depend on the type of set")
+
+	p := NewSparseSet(10)
+	p.Add(4)
+	p.Add(1)
+	p.Add(9)
+	p.Remove(9)
+	p.Add(7)
+	assert(t, p.Hash() == a.Hash(), "hash should not depend on the type of set")
+}
@@ -0,0 +1,66 @@
+package intset
+
+// An IDAllocator hands out integer identifiers less than its capacity,
+// each of which is in use until it is released. It is a ShrinkSet of the
+// identifiers that are available, so every operation takes O(1) time
+// and construction does no work beyond allocation.
+type IDAllocator struct {
+	available *ShrinkSet
+}
+
+// Allocate a new IDAllocator able to hand out the integers less than
+// capacity, all of which are initially available.
+func NewIDAllocator(capacity int) *IDAllocator {
+	return &IDAllocator{available: NewShrinkSet(capacity)}
+}
+
+// Returns an identifier that is not in use, and marks it as in use.
+// Which identifier is returned is arbitrary.
+// If every identifier is in use, the result will be 0 and error will be
+// ErrEmptySet.
+func (a *IDAllocator) Allocate() (int, error) {
+	return a.available.Pop()
+}
+
+// Returns the smallest identifier that is not in use, and marks it as in
+// use, for protocols such as file descriptor tables that require it.
+// This takes the time of ShrinkSet.PopMin: amortized O(1) while
+// identifiers are only allocated, but up to O(n), where n == capacity,
+// after an identifier smaller than those allocated since is released.
+// If every identifier is in use, the result will be 0 and error will be
+// ErrEmptySet.
+func (a *IDAllocator) AllocateLowest() (int, error) {
+	return a.available.PopMin()
+}
+
+// Marks id as no longer in use, so that it can be allocated again. It is
+// not an error to release an identifier that is not in use, or that is
+// too small or too large to have been allocated.
+func (a *IDAllocator) Release(id int) {
+	a.available.Add(id)
+}
+
+// Returns true if id has been allocated and not since released.
+func (a *IDAllocator) InUse(id int) bool {
+	return (*set)(a.available).inRange(id) && !a.available.Contains(id)
+}
+
+// Returns the number of identifiers that can still be allocated.
+func (a *IDAllocator) Available() int {
+	return a.available.Size()
+}
+
+// Returns the number of identifiers in use.
+func (a *IDAllocator) Allocated() int {
+	return a.available.Capacity() - a.available.Size()
+}
+
+// Returns the number of distinct identifiers the allocator can hand out.
+func (a *IDAllocator) Capacity() int {
+	return a.available.Capacity()
+}
+
+// Releases every identifier in O(1) time.
+func (a *IDAllocator) Reset() {
+	a.available.Refill()
+}
@@ -0,0 +1,61 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestIDAllocator(t *testing.T) {
+	a := NewIDAllocator(5)
+	assert(t, a.Available() == 5 && a.Allocated() == 0 && a.Capacity() == 5, "allocator should start empty")
+
+	seen := map[int]bool{}
+	for i := 0; i < 5; i++ {
+		id, err := a.Allocate()
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, id >= 0 && id < 5 && !seen[id], "bad id %v", id)
+		assert(t, a.InUse(id), "%v should be in use", id)
+		seen[id] = true
+	}
+
+	_, err := a.Allocate()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, a.Available() == 0 && a.Allocated() == 5, "every id should be in use")
+
+	a.Release(3)
+	a.Release(3)
+	a.Release(-1)
+	a.Release(5)
+	assert(t, !a.InUse(3) && !a.InUse(-1) && !a.InUse(5), "3 should be released")
+	assert(t, a.Available() == 1, "one id should be available")
+
+	id, err := a.Allocate()
+	assert(t, err == nil && id == 3, "3 should be reallocated, got %v", id)
+
+	a.Reset()
+	assert(t, a.Available() == 5 && !a.InUse(3), "reset should release every id")
+}
+
+func TestIDAllocatorLowest(t *testing.T) {
+	a := NewIDAllocator(6)
+	for want := 0; want < 6; want++ {
+		id, err := a.AllocateLowest()
+		assert(t, err == nil && id == want, "expected %v, got %v", want, id)
+	}
+
+	_, err := a.AllocateLowest()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	a.Release(4)
+	a.Release(1)
+	a.Release(2)
+	for _, want := range []int{1, 2, 4} {
+		id, err := a.AllocateLowest()
+		assert(t, err == nil && id == want, "expected %v, got %v", want, id)
+	}
+
+	a.Release(5)
+	a.Allocate()
+	a.Release(0)
+	id, _ := a.AllocateLowest()
+	assert(t, id == 0, "expected 0, got %v", id)
+}
@@ -0,0 +1,78 @@
+package intset
+
+// The members of a GrowSet, ShrinkSet, or SparseSet occupy the first
+// Size() slots of the dense array, and Index and At map between a member
+// and its slot, so that callers can keep per-member data in parallel
+// arrays indexed by slot. A member's slot is stable: adding a value never
+// moves an existing member, and removing a member moves only the member
+// in the last slot, into the slot that was vacated, so a parallel array
+// can be kept in step by making the same move. Pop removes the member in
+// the last slot, except that a ShrinkSet pops the member in the first.
+// Shuffle, Invert, and the operations that remove many members at once,
+// such as IntersectWith, may reassign every slot.
+
+// Returns the slot of the dense array holding value, and true, in O(1)
+// time. If value is not a member of the set, the result is 0 and false.
+func (g *GrowSet) Index(value int) (int, bool) {
+	if !g.Contains(value) {
+		return 0, false
+	}
+
+	return g.sparse[value-g.offset], true
+}
+
+// Returns the member of the set in slot i of the dense array, in O(1)
+// time.
+// If i is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (g *GrowSet) At(i int) (int, error) {
+	if i < 0 || i >= g.n {
+		return 0, outOfRange(i, 0, g.n)
+	}
+
+	return g.dense[i], nil
+}
+
+// Returns the slot of the dense array holding value, and true, in O(1)
+// time. If value is not a member of the set, the result is 0 and false.
+func (s *ShrinkSet) Index(value int) (int, bool) {
+	if !s.Contains(value) {
+		return 0, false
+	}
+
+	return s.indexOf(value), true
+}
+
+// Returns the member of the set in slot i of the dense array, in O(1)
+// time.
+// If i is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (s *ShrinkSet) At(i int) (int, error) {
+	if i < 0 || i >= s.n {
+		return 0, outOfRange(i, 0, s.n)
+	}
+
+	return s.at(i), nil
+}
+
+// Returns the slot of the dense array holding value, and true, in O(1)
+// time. If value is not a member of the set, the result is 0 and false.
+func (s *SparseSet) Index(value int) (int, bool) {
+	if !s.Contains(value) {
+		return 0, false
+	}
+
+	return s.sparse[value-s.offset], true
+}
+
+// Returns the member of the set in slot i of the dense array, in O(1)
+// time.
+// If i is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (s *SparseSet) At(i int) (int, error) {
+	if i < 0 || i >= s.n {
+		return 0, outOfRange(i, 0, s.n)
+	}
+
+	return s.dense[i], nil
+}
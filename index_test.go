@@ -0,0 +1,55 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+// The slot-indexed interface shared by GrowSet, ShrinkSet, and SparseSet.
+type indexed interface {
+	IntSet
+	Index(int) (int, bool)
+	At(int) (int, error)
+}
+
+func TestIndexAt(t *testing.T) {
+	s := NewShrinkSet(6)
+	s.Remove(1)
+	p := NewSparseSet(10)
+	for _, v := range []int{4, 9, 0, 6} {
+		p.Add(v)
+	}
+
+	for _, set := range []indexed{growSetOf(10, 2, 5, 7), s, p} {
+		for i, v := range set.Values() {
+			index, ok := set.Index(v)
+			assert(t, ok && index == i, "Index(%v) should be %v, not %v", v, i, index)
+
+			at, err := set.At(i)
+			assert(t, err == nil && at == v, "At(%v) should be %v, not %v", i, v, at)
+		}
+
+		_, ok := set.Index(1)
+		assert(t, !ok, "1 should not have an index")
+		_, err := set.At(set.Size())
+		assert(t, errors.Is(err, ErrValueOutOfRange), "At(Size()) should fail")
+		_, err = set.At(-1)
+		assert(t, errors.Is(err, ErrValueOutOfRange), "At(-1) should fail")
+	}
+}
+
+func TestIndexStable(t *testing.T) {
+	set := NewSparseSet(10)
+	for _, v := range []int{3, 1, 4, 5} {
+		set.Add(v)
+	}
+
+	// Removing 1 moves only the last member, 5, into its slot.
+	before, _ := set.Index(1)
+	set.Remove(1)
+	set.Add(9)
+	for v, want := range map[int]int{3: 0, 5: before, 4: 2, 9: 3} {
+		index, ok := set.Index(v)
+		assert(t, ok && index == want, "Index(%v) should be %v, not %v", v, want, index)
+	}
+}
@@ -0,0 +1,235 @@
+package intset
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"sort"
+)
+
+// An IntervalSet stores its members as a sorted list of runs of
+// consecutive integers, so a set made up of a few long runs, such as a
+// block allocation map, takes very little memory however many members
+// it has. It can hold any int, but Size reports at most math.MaxInt,
+// although a set can have up to 2^64 members.
+//
+// Contains takes O(log r) time, where r is the number of runs. Adding
+// or removing a value or range takes O(log r) time to find the runs
+// involved, plus time proportional to the number of runs after them,
+// which must be shifted. Unlike the other set types, Values allocates a
+// new slice on every call; Runs iterates over the runs directly.
+type IntervalSet struct {
+	// The number of members, which wraps to zero when the set holds
+	// every int.
+	n    uint
+	runs []interval
+}
+
+// A run of the integers from start up to and including last. The last
+// member is stored, rather than the one after it, so that a run can end
+// at math.MaxInt.
+type interval struct {
+	start int
+	last  int
+}
+
+// Returns the number of members in run, which wraps to zero for the run
+// of every int.
+func (run interval) length() uint {
+	return uint(run.last) - uint(run.start) + 1
+}
+
+// Create a new, empty IntervalSet.
+func NewIntervalSet() *IntervalSet {
+	return &IntervalSet{}
+}
+
+// Returns the index of the first run ending at or after value.
+func (s *IntervalSet) search(value int) int {
+	return sort.Search(len(s.runs), func(i int) bool {
+		return s.runs[i].last >= value
+	})
+}
+
+// Returns true if value is a member of the set.
+func (s *IntervalSet) Contains(value int) bool {
+	i := s.search(value)
+	return i < len(s.runs) && s.runs[i].start <= value
+}
+
+// Removes all elements from the set.
+func (s *IntervalSet) Clear() {
+	s.n = 0
+	s.runs = s.runs[:0]
+}
+
+// Returns the size of the set, or math.MaxInt if it has more members
+// than that.
+func (s *IntervalSet) Size() int {
+	if s.n > math.MaxInt || (s.n == 0 && len(s.runs) > 0) {
+		return math.MaxInt
+	}
+
+	return int(s.n)
+}
+
+// Returns the number of runs of consecutive members in the set.
+func (s *IntervalSet) RunCount() int {
+	return len(s.runs)
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// Every int can be stored in an IntervalSet, so the error is always
+// nil; it is returned for consistency with the other set types.
+func (s *IntervalSet) Add(value int) error {
+	s.add(value, value)
+	return nil
+}
+
+// Adds every integer from lo up to, but not including, hi to the set,
+// merging any runs that it touches. Add must be used for math.MaxInt.
+// The error is always nil; it is returned for consistency with the
+// other set types.
+func (s *IntervalSet) AddRange(lo, hi int) error {
+	if lo < hi {
+		s.add(lo, hi-1)
+	}
+
+	return nil
+}
+
+// Adds every integer from first up to and including last to the set.
+func (s *IntervalSet) add(first, last int) {
+	// Runs i up to j overlap or adjoin the new run, and are replaced by
+	// a single run covering all of them. No run can adjoin one starting
+	// at math.MinInt or ending at math.MaxInt from outside.
+	i := 0
+	if first > math.MinInt {
+		i = s.search(first - 1)
+	}
+
+	j := i
+	for j < len(s.runs) && (s.runs[j].start <= last || s.runs[j].start-1 == last) {
+		first = min(first, s.runs[j].start)
+		last = max(last, s.runs[j].last)
+		s.n -= s.runs[j].length()
+		j++
+	}
+
+	run := interval{first, last}
+	s.runs = slices.Replace(s.runs, i, j, run)
+	s.n += run.length()
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *IntervalSet) Remove(item int) {
+	s.remove(item, item)
+}
+
+// Removes every integer from lo up to, but not including, hi from the
+// set, splitting a run if the range falls within it. It is not an error
+// for the range to include values that are not in the set. Remove must
+// be used for math.MaxInt.
+func (s *IntervalSet) RemoveRange(lo, hi int) {
+	if lo < hi {
+		s.remove(lo, hi-1)
+	}
+}
+
+// Removes every integer from first up to and including last from the
+// set.
+func (s *IntervalSet) remove(first, last int) {
+	// Runs i up to j overlap the range. Only the first and last of them
+	// can extend beyond it.
+	i := s.search(first)
+	j := i
+	var remaining []interval
+	for j < len(s.runs) && s.runs[j].start <= last {
+		run := s.runs[j]
+		s.n -= interval{max(run.start, first), min(run.last, last)}.length()
+		if run.start < first {
+			remaining = append(remaining, interval{run.start, first - 1})
+		}
+
+		if run.last > last {
+			remaining = append(remaining, interval{last + 1, run.last})
+		}
+
+		j++
+	}
+
+	if i == j {
+		return
+	}
+
+	s.runs = slices.Replace(s.runs, i, j, remaining...)
+}
+
+// Remove and return the largest member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *IntervalSet) Pop() (int, error) {
+	if len(s.runs) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	run := &s.runs[len(s.runs)-1]
+	value := run.last
+	if run.start == run.last {
+		s.runs = s.runs[:len(s.runs)-1]
+	} else {
+		run.last--
+	}
+
+	s.n--
+	return value, nil
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (s *IntervalSet) Values() []int {
+	result := make([]int, 0, s.Size())
+	for v := range s.All() {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members of the set, in increasing order.
+// The set should not be modified during iteration.
+func (s *IntervalSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for _, run := range s.runs {
+			for v := run.start; ; v++ {
+				if !yield(v) {
+					return
+				}
+
+				if v == run.last {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Returns an iterator over the runs of consecutive members of the set,
+// in increasing order, yielding the first member of each run and the
+// number of members in it, or math.MaxInt if it has more members than
+// that.
+// The set should not be modified during iteration.
+func (s *IntervalSet) Runs() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, run := range s.runs {
+			length := run.length()
+			if length > math.MaxInt || length == 0 {
+				length = math.MaxInt
+			}
+
+			if !yield(run.start, int(length)) {
+				return
+			}
+		}
+	}
+}
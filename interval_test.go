@@ -0,0 +1,138 @@
+package intset
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIntervalSet(t *testing.T) {
+	set := NewIntervalSet()
+
+	set.AddRange(10, 20)
+	set.AddRange(30, 40)
+	set.Add(20)
+	set.Add(-5)
+
+	assert(t, set.Size() == 22, "set size should be 22, got %v", set.Size())
+	assert(t, set.RunCount() == 3, "set should have 3 runs, got %v", set.RunCount())
+
+	for _, v := range []int{-5, 10, 20, 30, 39} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{-6, -4, 9, 21, 29, 40} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	set.AddRange(21, 30)
+	assert(t, set.RunCount() == 2, "adjoining runs should merge")
+
+	set.RemoveRange(15, 35)
+	set.Remove(-5)
+	set.Remove(-5)
+
+	var runs [][2]int
+	for start, length := range set.Runs() {
+		runs = append(runs, [2]int{start, length})
+	}
+	assert(t, slices.Equal(runs, [][2]int{{10, 5}, {35, 5}}), "runs are wrong: %v", runs)
+	assert(t, set.Size() == 10, "set size should be 10")
+
+	v, err := set.Pop()
+	assert(t, err == nil && v == 39, "pop should return the largest member")
+
+	values := set.Values()
+	assert(t, slices.Equal(values, []int{10, 11, 12, 13, 14, 35, 36, 37, 38}), "values are wrong: %v", values)
+
+	set.Clear()
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestIntervalSetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set := NewIntervalSet()
+	members := make([]bool, 200)
+
+	for i := 0; i < 5000; i++ {
+		lo := r.Intn(200)
+		hi := lo + r.Intn(min(20, 200-lo)+1)
+		add := r.Intn(2) == 0
+		if add {
+			set.AddRange(lo, hi)
+		} else {
+			set.RemoveRange(lo, hi)
+		}
+
+		for v := lo; v < hi; v++ {
+			members[v] = add
+		}
+
+		size := 0
+		for v, ok := range members {
+			assert(t, set.Contains(v) == ok, "membership of %v is wrong", v)
+			if ok {
+				size++
+			}
+		}
+		assert(t, set.Size() == size, "set size should be %v", size)
+
+		end := -1
+		for start, length := range set.Runs() {
+			assert(t, length > 0 && start > end, "runs should be separate and non-empty")
+			end = start + length
+		}
+	}
+}
+
+func TestIntervalSetExtremes(t *testing.T) {
+	set := NewIntervalSet()
+	set.Add(5)
+	set.AddRange(math.MinInt, math.MinInt+3)
+	for _, v := range []int{math.MinInt, math.MinInt + 2, 5} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+	assert(t, !set.Contains(math.MinInt+3), "set should not contain MinInt+3")
+	assert(t, set.Size() == 4 && set.RunCount() == 2, "set should have 4 members in 2 runs")
+
+	set.Add(math.MaxInt)
+	set.Add(math.MaxInt - 1)
+	assert(t, set.Contains(math.MaxInt) && set.Size() == 6, "set should contain MaxInt")
+	v, ok := set.PrevBefore(math.MaxInt)
+	assert(t, ok && v == math.MaxInt-1, "PrevBefore(MaxInt) should be MaxInt-1, not %v", v)
+	assert(t, slices.Equal(set.Values()[4:], []int{math.MaxInt - 1, math.MaxInt}), "values are wrong: %v", set.Values())
+
+	v, err := set.Pop()
+	assert(t, err == nil && v == math.MaxInt, "pop should return MaxInt, not %v", v)
+	set.Add(math.MaxInt)
+	set.Remove(math.MaxInt)
+	assert(t, !set.Contains(math.MaxInt) && set.Size() == 5, "Remove(MaxInt) should remove it")
+	set.Remove(math.MinInt)
+	assert(t, !set.Contains(math.MinInt) && set.Contains(math.MinInt+1), "Remove(MinInt) should remove only it")
+
+	// Every int, in two halves, and then all but one.
+	set.Clear()
+	set.AddRange(math.MinInt, 0)
+	set.AddRange(0, math.MaxInt)
+	assert(t, set.Size() == math.MaxInt, "size should saturate at MaxInt, got %v", set.Size())
+	set.Add(math.MaxInt)
+	assert(t, set.RunCount() == 1 && set.Size() == math.MaxInt, "a set of every int should have one run")
+	for start, length := range set.Runs() {
+		assert(t, start == math.MinInt && length == math.MaxInt, "run should start at MinInt with saturated length")
+	}
+
+	set.RemoveRange(math.MinInt, 0)
+	set.Remove(0)
+	assert(t, set.Size() == math.MaxInt && !set.Contains(0) && set.Contains(1), "set should hold exactly the positive ints")
+	set.Remove(1)
+	assert(t, set.Size() == math.MaxInt-1, "set size should be MaxInt-1, got %v", set.Size())
+
+	// A set of every int counts 2^64 members, which wraps to zero.
+	set.AddRange(math.MinInt, math.MaxInt)
+	set.Add(math.MaxInt)
+	v, err = set.Pop()
+	assert(t, err == nil && v == math.MaxInt, "pop from a full set should return MaxInt, not %v, %v", v, err)
+	assert(t, !set.Contains(math.MaxInt) && set.Contains(math.MaxInt-1), "pop should remove only MaxInt")
+}
@@ -10,23 +10,197 @@
 //
 // The various data structures provide other operations which may be useful
 // in different situations.
+//
+// Constructors panic with a descriptive message if they are given a
+// negative capacity, or a range whose maximum is less than its minimum.
+// A set with a capacity of zero is valid, but can hold no values: adding
+// to it returns ErrValueOutOfRange, and popping from it returns
+// ErrEmptySet.
+//
+// The zero value of a GrowSet, ShrinkSet, or SparseSet has no storage:
+// it contains nothing, and adding to it returns ErrUninitialized, so
+// that a set that was declared but never constructed is easy to detect.
+// The zero value of a DynamicSet is an empty set ready to use.
 package intset
 
 import (
 	"errors"
+	"fmt"
 )
 
 // Returned when an operation (e.g. Pop) that returns a value from the set
 // is requested on an empty set.
-var EmptySetError = errors.New("empty set")
+var ErrEmptySet = errors.New("empty set")
 
 // Returned when a value is too large or small to fit in a constructed set.
-var ValueOutOfRangeError = errors.New("value out of range")
+// Operations on a single value return a *RangeError wrapping it, so it
+// should be tested for with errors.Is rather than ==.
+var ErrValueOutOfRange = errors.New("value out of range")
+
+// Returned when adding to a set that was declared rather than created by
+// a constructor, and so has no storage.
+var ErrUninitialized = errors.New("set not initialized")
+
+// Returned when decoding a set from data that is not a valid encoding.
+var ErrInvalidEncoding = errors.New("invalid encoding")
+
+// Returned when parsing a set from a string that is not valid range notation.
+var ErrInvalidSyntax = errors.New("invalid syntax")
+
+// Returned when rolling a set back to a checkpoint that it has since
+// been changed in a way that cannot be undone.
+var ErrInvalidCheckpoint = errors.New("invalid checkpoint")
+
+// Returned when adding to a CuckooFilter that has no room left for
+// another value.
+var ErrFilterFull = errors.New("filter full")
+
+// Returned by CheckInvariants when a set's internal state is
+// inconsistent. The error describing the inconsistency wraps it.
+var ErrCorrupt = errors.New("set corrupt")
+
+// The largest capacity that Parse, the decoding functions, and
+// NewStealingSet will give a set sized from their input, so that a
+// short, hostile input or a single huge member cannot make them allocate
+// without bound. Programs that build larger sets from trusted sources
+// may raise it.
+var MaxDecodedCapacity = 1 << 28
+
+// The names the errors above had before they followed the Err naming
+// convention. Each is the same value as its replacement, so errors.Is and
+// == work with either name.
+var (
+	// Deprecated: Use ErrEmptySet.
+	EmptySetError = ErrEmptySet
+
+	// Deprecated: Use ErrValueOutOfRange.
+	ValueOutOfRangeError = ErrValueOutOfRange
+
+	// Deprecated: Use ErrInvalidEncoding.
+	InvalidEncodingError = ErrInvalidEncoding
+
+	// Deprecated: Use ErrInvalidSyntax.
+	InvalidSyntaxError = ErrInvalidSyntax
+
+	// Deprecated: Use ErrInvalidCheckpoint.
+	InvalidCheckpointError = ErrInvalidCheckpoint
+)
+
+// Returned when a value is too small or too large to be stored in a set.
+// The set can hold the integers from Min up to, but not including, Max,
+// so its capacity is Max - Min.
+// errors.Is reports a RangeError as ErrValueOutOfRange.
+type RangeError struct {
+	Value int
+	Min   int
+	Max   int
+}
+
+// Returns a description of the error, giving the value and the range.
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("value %d out of range [%d, %d)", e.Value, e.Min, e.Max)
+}
+
+// Returns ErrValueOutOfRange, for errors.Is.
+func (e *RangeError) Unwrap() error {
+	return ErrValueOutOfRange
+}
+
+// Returns a *RangeError for value, which does not lie between lo and hi.
+func outOfRange(value, lo, hi int) error {
+	return &RangeError{Value: value, Min: lo, Max: hi}
+}
+
+// IntSet is implemented by every set type in this package, allowing
+// code to be written without regard to which kind of set it holds.
+type IntSet interface {
+	// Returns true if value is a member of the set.
+	Contains(value int) bool
+
+	// Returns the number of members in the set.
+	Size() int
+
+	// Returns a slice containing the members of the set.
+	// This slice should not be modified.
+	Values() []int
+
+	// Remove and return an arbitrary member of the set.
+	// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+	Pop() (int, error)
+}
+
+// Adder is implemented by the set types in this package that values can
+// be added to, so that operations can write their results into a set of
+// the caller's choosing.
+type Adder interface {
+	// Adds value to the set, returning ErrValueOutOfRange if it
+	// cannot be stored there.
+	Add(value int) error
+}
+
+var (
+	_ IntSet = (*GrowSet)(nil)
+	_ IntSet = (*ShrinkSet)(nil)
+	_ IntSet = (*SparseSet)(nil)
+	_ IntSet = (*ShardedSet)(nil)
+	_ IntSet = (*StealingSet)(nil)
+	_ IntSet = (*AtomicGrowSet)(nil)
+	_ IntSet = (*DynamicSet)(nil)
+	_ IntSet = (*BitSet)(nil)
+	_ IntSet = (*AdaptiveSet)(nil)
+	_ IntSet = (*ChunkedSet)(nil)
+	_ IntSet = (*MultiSet)(nil)
+	_ IntSet = (*IntervalSet)(nil)
+	_ IntSet = (*GroupedSet)(nil)
+	_ IntSet = (*ClockSet)(nil)
+	_ IntSet = (*LRUSet)(nil)
+	_ IntSet = (*PrioritySet[int])(nil)
+	_ IntSet = (*SummedSet)(nil)
+	_ IntSet = (*VEBSet)(nil)
+	_ IntSet = (*PagedSet)(nil)
+	_ IntSet = (*MeteredSet)(nil)
+	_ IntSet = (*ObservedSet)(nil)
+	_ IntSet = (*OrderedSet)(nil)
+	_ IntSet = (*CompactGrowSet[uint32])(nil)
+	_ IntSet = (*CompactShrinkSet[uint32])(nil)
+	_ IntSet = (*CompactSparseSet[uint32])(nil)
+)
 
 type set struct {
 	n      int
 	sparse []int
 	dense  []int
+
+	// The smallest value the set can hold. Members are stored in dense
+	// as themselves, but sparse is indexed by value - offset.
+	offset int
+
+	// When lazy is true, a zero entry in sparse or dense that has never
+	// been written stands for its own index. See ShrinkSet.at.
+	lazy bool
+
+	// When inverted is true, a ShrinkSet uses its dense array back to
+	// front. See ShrinkSet.Invert.
+	inverted bool
+
+	// The smallest and largest members of the set. See minmax.go for how
+	// each set type keeps these up to date.
+	min   int
+	max   int
+	stale bool
+
+	// When shared is true, sparse and dense may also be in use by a
+	// fork of the set, and must be copied before being written. See
+	// fork.go.
+	shared bool
+
+	// Increased by at least the number of members removed by each
+	// change to the set, and by at least one more if the change moves
+	// members within the dense array, so that iterators can detect
+	// changes made during iteration. Adding members does not change it,
+	// since they are placed past the members already there. See
+	// iter.go.
+	mods uint
 }
 
 // A GrowSet starts out empty and can have items added to it.
@@ -40,22 +214,146 @@ type GrowSet set
 // Allocate a new GrowSet.
 // The resulting set will be able to store the integers less than
 // capacity.
-// Construction takes O(1) time.
+// The set itself needs no initialization, but Go zeroes newly allocated
+// memory, so construction may take O(n) time, where n == capacity.
+// See NewGrowSetFromBuffer for a way to avoid this.
 func NewGrowSet(capacity int) *GrowSet {
+	validateCapacity(capacity)
+	return NewGrowSetRange(0, capacity)
+}
+
+// Allocate a new GrowSet able to store the integers from min up to, but
+// not including, max. Either bound may be negative, and only max - min
+// entries are allocated, so a set of large values close together is as
+// cheap as one of small values.
+func NewGrowSetRange(min, max int) *GrowSet {
+	validateRange(min, max)
+	capacity := max - min
 	return &GrowSet{
 		n:      0,
 		sparse: make([]int, capacity, capacity),
 		dense:  make([]int, capacity, capacity),
+		offset: min,
+	}
+}
+
+// Allocate a new GrowSet able to store the integers less than capacity,
+// containing the members of values. Duplicates in values are ignored.
+// If any of values is less than zero or too large to be stored in the
+// set, the result will be nil and error will be ErrValueOutOfRange.
+func NewGrowSetFromSlice(capacity int, values []int) (*GrowSet, error) {
+	for _, v := range values {
+		if v < 0 || v >= capacity {
+			return nil, outOfRange(v, 0, capacity)
+		}
+	}
+
+	result := NewGrowSet(capacity)
+	for _, v := range values {
+		result.Add(v)
+	}
+
+	return result, nil
+}
+
+// Create a new, empty GrowSet using buffer as its storage.
+// The resulting set will be able to store the integers less than
+// len(buffer) / 2.
+// The contents of buffer do not need to be cleared beforehand, so a
+// buffer taken from a previous set can be reused in O(1) time,
+// regardless of its size. The buffer must not be used by anything else
+// for the lifetime of the set.
+func NewGrowSetFromBuffer(buffer []int) *GrowSet {
+	if buffer == nil {
+		buffer = []int{}
 	}
+
+	capacity := len(buffer) / 2
+	return &GrowSet{
+		n:      0,
+		sparse: buffer[:capacity:capacity],
+		dense:  buffer[capacity : 2*capacity : 2*capacity],
+	}
+}
+
+// Create a new, empty GrowSet using sparse and dense as its arrays, so
+// that they can be placed in an arena, a pool, or any other memory the
+// caller controls. The resulting set will be able to store the integers
+// less than len(sparse).
+// As with NewGrowSetFromBuffer, the contents of the slices do not need
+// to be cleared beforehand, and they must not be used by anything else
+// for the lifetime of the set. It panics if the slices differ in length.
+func NewGrowSetWithBuffers(sparse, dense []int) *GrowSet {
+	sparse, dense = validateBuffers(sparse, dense)
+	return &GrowSet{sparse: sparse, dense: dense}
+}
+
+// Panics if capacity is negative, rather than leaving make to panic with
+// a less helpful message.
+func validateCapacity(capacity int) {
+	if capacity < 0 {
+		panic(fmt.Sprintf("intset: negative capacity %d", capacity))
+	}
+}
+
+// Panics if sparse and dense differ in length. Otherwise returns them,
+// replacing nil slices with empty ones, so that a set with no capacity
+// is not mistaken for a zero value.
+func validateBuffers(sparse, dense []int) ([]int, []int) {
+	if len(sparse) != len(dense) {
+		panic(fmt.Sprintf("intset: sparse and dense buffers differ in length (%d and %d)", len(sparse), len(dense)))
+	}
+
+	if sparse == nil || dense == nil {
+		return []int{}, []int{}
+	}
+
+	return sparse, dense
+}
+
+// Panics if max is less than min, rather than leaving make to panic
+// with a less helpful message.
+func validateRange(min, max int) {
+	if max < min {
+		panic(fmt.Sprintf("intset: range maximum %d is less than minimum %d", max, min))
+	}
+}
+
+// Returns true if value lies within the range of values the set can hold.
+func (s *set) inRange(value int) bool {
+	return value >= s.offset && value-s.offset < len(s.sparse)
+}
+
+// Returns the smallest value the set can hold, and one more than the
+// largest.
+func (s *set) bounds() (int, int) {
+	return s.offset, s.offset + len(s.sparse)
+}
+
+// Returns a *RangeError for value, which the set cannot hold, or
+// ErrUninitialized if the set is a zero value.
+func (s *set) outOfRange(value int) error {
+	if s.sparse == nil {
+		return ErrUninitialized
+	}
+
+	lo, hi := s.bounds()
+	return outOfRange(value, lo, hi)
 }
 
 // Returns true if value is a member of the set.
 func (g *GrowSet) Contains(value int) bool {
-	return value < len(g.sparse) && g.sparse[value] < g.n && g.dense[g.sparse[value]] == value
+	if !(*set)(g).inRange(value) {
+		return false
+	}
+
+	index := g.sparse[value-g.offset]
+	return index >= 0 && index < g.n && g.dense[index] == value
 }
 
 // Removes all elements from the set.
 func (g *GrowSet) Clear() {
+	g.mods += uint(g.n)
 	g.n = 0
 }
 
@@ -64,32 +362,55 @@ func (g *GrowSet) Size() int {
 	return g.n
 }
 
+// Returns the number of distinct values the set is able to store.
+func (g *GrowSet) Capacity() int {
+	return len(g.sparse)
+}
+
 // Adds value to the set. Adding the same value multiple times is not an error.
-// If a value is less than zero or too large to be stored in the set, ValueOutOfRangeError
+// If a value is too small or too large to be stored in the set, ErrValueOutOfRange
 // is returned, otherwise nil.
 func (g *GrowSet) Add(value int) error {
-	if value >= len(g.sparse) || value < 0 {
-		return ValueOutOfRangeError
+	_, err := g.AddReported(value)
+	return err
+}
+
+// Adds value to the set, like Add, and returns true if it was not
+// already a member. This saves a separate call to Contains when the
+// caller needs to know.
+// If a value is too small or too large to be stored in the set, the
+// result will be false and error will be ErrValueOutOfRange.
+func (g *GrowSet) AddReported(value int) (bool, error) {
+	if !(*set)(g).inRange(value) {
+		return false, (*set)(g).outOfRange(value)
 	}
 
-	if !g.Contains(value) {
-		g.dense[g.n] = value
-		g.sparse[value] = g.n
-		g.n++
+	if g.Contains(value) {
+		return false, nil
 	}
 
-	return nil
+	(*set)(g).own()
+	g.extend(value)
+	g.dense[g.n] = value
+	g.sparse[value-g.offset] = g.n
+	g.n++
+
+	return true, nil
 }
 
-// Remove and return a random value from the set.
-// If the set is empty, the result will be 0 and error will be EmptySetError.
+// Remove and return the most recently added member of the set.
+// That is, a GrowSet pops in last-in, first-out order; use PopRandom
+// for a uniformly random member.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
 func (g *GrowSet) Pop() (int, error) {
 	if g.n == 0 {
-		return 0, EmptySetError
+		return 0, ErrEmptySet
 	}
 
 	value := g.dense[g.n-1]
 	g.n--
+	g.mods++
+	g.stale = g.stale || value == g.min || value == g.max
 	return value, nil
 }
 
@@ -105,34 +426,152 @@ func (g *GrowSet) Values() []int {
 //
 //   Remove(n) - Remove n from the set in O(1) time.
 //   Refill() - Refills the set in O(1) time.
+//   Add(n) - Add a previously removed n back to the set in O(1) time.
 type ShrinkSet set
 
 // Create a new ShrinkSet storing the numbers up to,
-// but not including, capacity. This takes O(n) time,
-// where n == capacity.
+// but not including, capacity. The set is initialized lazily, so
+// beyond the allocation itself this takes O(1) time; the first call to
+// Values takes O(n) time, where n == capacity.
 func NewShrinkSet(capacity int) *ShrinkSet {
-	result := &ShrinkSet{
+	validateCapacity(capacity)
+	return NewShrinkSetRange(0, capacity)
+}
+
+// Create a new ShrinkSet storing the numbers from min up to, but not
+// including, max. Either bound may be negative, and only max - min
+// entries are allocated. Like NewShrinkSet, this initializes the set
+// lazily.
+func NewShrinkSetRange(min, max int) *ShrinkSet {
+	validateRange(min, max)
+	capacity := max - min
+	return &ShrinkSet{
 		n:      capacity,
 		sparse: make([]int, capacity, capacity),
 		dense:  make([]int, capacity, capacity),
+		offset: min,
+		lazy:   true,
+		min:    min,
+		max:    max - 1,
+	}
+}
+
+// Create a new ShrinkSet storing the numbers up to, but not including,
+// len(sparse), using sparse and dense as its arrays, so that they can be
+// placed in an arena, a pool, or any other memory the caller controls.
+// The slices are cleared so that the set can be initialized lazily,
+// which takes O(n) time, where n == len(sparse). They must not be used
+// by anything else for the lifetime of the set. It panics if the slices
+// differ in length.
+func NewShrinkSetWithBuffers(sparse, dense []int) *ShrinkSet {
+	sparse, dense = validateBuffers(sparse, dense)
+	clear(sparse)
+	clear(dense)
+
+	capacity := len(sparse)
+	return &ShrinkSet{
+		n:      capacity,
+		sparse: sparse,
+		dense:  dense,
+		lazy:   true,
+		max:    capacity - 1,
 	}
+}
 
-	for i := 0; i < capacity; i++ {
-		result.sparse[i] = i
-		result.dense[i] = i
+// Returns the position in the dense array of index i. Unless the set is
+// inverted, these are the same; this is its own inverse.
+func (s *ShrinkSet) position(i int) int {
+	if s.inverted {
+		return len(s.dense) - 1 - i
 	}
 
-	return result
+	return i
+}
+
+// Returns the value at index i of the dense array.
+func (s *ShrinkSet) at(i int) int {
+	return s.load(s.position(i))
+}
+
+// Returns the value at position p of the dense array.
+// While the set is lazy, the dense array holds values relative to the
+// offset of the set. The arrays start out zeroed, and a zero entry
+// means either that the slot holds zero or that it has never been
+// written and so holds its own index. The position of zero itself
+// disambiguates: it is always stored exactly, since an unwritten
+// sparse[0] correctly says that zero is at index 0.
+func (s *ShrinkSet) load(p int) int {
+	v := s.dense[p]
+	if !s.lazy {
+		return v
+	}
+
+	if v != 0 || s.sparse[0] == p {
+		return v + s.offset
+	}
+
+	return p + s.offset
+}
+
+// Returns the index of value in the dense array.
+// This is the inverse of at, and disambiguates zero entries the
+// same way.
+func (s *ShrinkSet) indexOf(value int) int {
+	relative := value - s.offset
+	if p := s.sparse[relative]; p != 0 || !s.lazy || s.dense[0] == relative {
+		return s.position(p)
+	}
+
+	return s.position(relative)
+}
+
+// Stores value at index i of the dense array, and records its position
+// in the sparse array.
+func (s *ShrinkSet) put(i, value int) {
+	(*set)(s).own()
+	p := s.position(i)
+	if s.lazy {
+		s.dense[p] = value - s.offset
+	} else {
+		s.dense[p] = value
+	}
+
+	s.sparse[value-s.offset] = p
+}
+
+// Fills in every entry of the arrays that has never been written,
+// so that they can be used directly.
+func (s *ShrinkSet) materialize() {
+	if !s.lazy {
+		return
+	}
+
+	(*set)(s).own()
+	for p := range s.dense {
+		s.dense[p] = s.load(p)
+	}
+
+	// The dense array now holds absolute values, so the relative value
+	// that indexOf would compare against dense[0] is computed here.
+	for r := range s.sparse {
+		if i := s.sparse[r]; i == 0 && s.dense[0]-s.offset != r {
+			s.sparse[r] = r
+		}
+	}
+
+	s.lazy = false
 }
 
 // Returns true if value is in the set.
 func (s *ShrinkSet) Contains(value int) bool {
-	return value < len(s.sparse) && s.sparse[value] < s.n
+	return (*set)(s).inRange(value) && s.indexOf(value) < s.n
 }
 
 // Resets the set to its original state in O(1) time.
 func (s *ShrinkSet) Refill() {
 	s.n = len(s.dense)
+	s.min = s.offset
+	s.max = s.offset + len(s.dense) - 1
 }
 
 // Returns the number of elements in the set.
@@ -140,37 +579,232 @@ func (s *ShrinkSet) Size() int {
 	return s.n
 }
 
+// Returns the number of distinct values the set is able to store.
+func (s *ShrinkSet) Capacity() int {
+	return len(s.sparse)
+}
+
 // Returns a slice containing the members of the set.
 // This slice should not be modified.
 func (g *ShrinkSet) Values() []int {
+	g.materialize()
+	if g.inverted {
+		return g.dense[len(g.dense)-g.n:]
+	}
+
 	return g.dense[:g.n]
 }
 
+// Returns a slice containing the values that have been removed from the
+// set, that is, the values it can hold that are not members. The set
+// keeps these in the dense array after its members, so this takes O(1)
+// time, except that like Values the first call takes O(n) time, where
+// n == capacity.
+// This slice should not be modified.
+func (s *ShrinkSet) RemovedValues() []int {
+	s.materialize()
+	if s.inverted {
+		return s.dense[:len(s.dense)-s.n]
+	}
+
+	return s.dense[s.n:]
+}
+
 // Remove the item from the set. It is not an error to
 // remove an item that does not exist.
 func (s *ShrinkSet) Remove(item int) {
 	if s.Contains(item) {
-		itemIndex := s.sparse[item]
-		lastItem := s.dense[s.n-1]
-		lastItemIndex := s.sparse[lastItem]
+		itemIndex := s.indexOf(item)
+		lastItem := s.at(s.n - 1)
 
-		s.dense[lastItemIndex] = item
-		s.dense[itemIndex] = lastItem
-		s.sparse[lastItem] = itemIndex
-		s.sparse[item] = lastItemIndex
+		s.put(s.n-1, item)
+		s.put(itemIndex, lastItem)
 		s.n--
+		s.mods++
 	}
 }
 
-// Remove and return a random member from the set.
+// Remove and return the member at the front of the set's internal
+// ordering. This is deterministic, but the order depends on the
+// history of removals and is not meaningful to callers. Use PopRandom
+// for a uniformly random member.
 // If the set is empty, the result will be zero and
-// error will be EmptySetError.
+// error will be ErrEmptySet.
 func (s *ShrinkSet) Pop() (int, error) {
 	if s.n == 0 {
-		return 0, EmptySetError
+		return 0, ErrEmptySet
 	}
 
-	removed := s.dense[0]
+	removed := s.at(0)
 	s.Remove(removed)
 	return removed, nil
 }
+
+// Adds a previously removed value back to the set.
+// Adding a value that is already in the set is not an error.
+// If a value is too small or too large to have been in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (s *ShrinkSet) Add(value int) error {
+	_, err := s.AddReported(value)
+	return err
+}
+
+// Adds a previously removed value back to the set, like Add, and
+// returns true if it was not already a member. This saves a separate
+// call to Contains when the caller needs to know.
+// If a value is too small or too large to have been in the set, the
+// result will be false and error will be ErrValueOutOfRange.
+func (s *ShrinkSet) AddReported(value int) (bool, error) {
+	if !(*set)(s).inRange(value) {
+		return false, (*set)(s).outOfRange(value)
+	}
+
+	if s.Contains(value) {
+		return false, nil
+	}
+
+	s.extend(value)
+	valueIndex := s.indexOf(value)
+	firstRemoved := s.at(s.n)
+
+	s.put(s.n, value)
+	s.put(valueIndex, firstRemoved)
+	s.n++
+
+	return true, nil
+}
+
+// A SparseSet starts out empty and can have items both added to
+// and removed from it. It supports the following additional operations
+// with the associated time complexity:
+//
+//   Add(n)    - Add integer n to the set, in O(1) time.
+//   Remove(n) - Remove n from the set, in O(1) time.
+//   Clear()   - Removes all elements from the set, in O(1) time.
+type SparseSet set
+
+// Allocate a new SparseSet.
+// The resulting set will be able to store the integers less than
+// capacity.
+func NewSparseSet(capacity int) *SparseSet {
+	validateCapacity(capacity)
+	return NewSparseSetRange(0, capacity)
+}
+
+// Allocate a new SparseSet able to store the integers from min up to,
+// but not including, max. Either bound may be negative, and only
+// max - min entries are allocated.
+func NewSparseSetRange(min, max int) *SparseSet {
+	validateRange(min, max)
+	capacity := max - min
+	return &SparseSet{
+		n:      0,
+		sparse: make([]int, capacity, capacity),
+		dense:  make([]int, capacity, capacity),
+		offset: min,
+	}
+}
+
+// Create a new, empty SparseSet using sparse and dense as its arrays, so
+// that they can be placed in an arena, a pool, or any other memory the
+// caller controls. The resulting set will be able to store the integers
+// less than len(sparse).
+// The contents of the slices do not need to be cleared beforehand, but
+// they must not be used by anything else for the lifetime of the set.
+// It panics if the slices differ in length.
+func NewSparseSetWithBuffers(sparse, dense []int) *SparseSet {
+	sparse, dense = validateBuffers(sparse, dense)
+	return &SparseSet{sparse: sparse, dense: dense}
+}
+
+// Returns true if value is a member of the set.
+func (s *SparseSet) Contains(value int) bool {
+	if !(*set)(s).inRange(value) {
+		return false
+	}
+
+	index := s.sparse[value-s.offset]
+	return index >= 0 && index < s.n && s.dense[index] == value
+}
+
+// Removes all elements from the set.
+func (s *SparseSet) Clear() {
+	s.mods += uint(s.n)
+	s.n = 0
+}
+
+// Returns the size of the set.
+func (s *SparseSet) Size() int {
+	return s.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *SparseSet) Capacity() int {
+	return len(s.sparse)
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is too small or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (s *SparseSet) Add(value int) error {
+	_, err := s.AddReported(value)
+	return err
+}
+
+// Adds value to the set, like Add, and returns true if it was not
+// already a member. This saves a separate call to Contains when the
+// caller needs to know.
+// If a value is too small or too large to be stored in the set, the
+// result will be false and error will be ErrValueOutOfRange.
+func (s *SparseSet) AddReported(value int) (bool, error) {
+	if !(*set)(s).inRange(value) {
+		return false, (*set)(s).outOfRange(value)
+	}
+
+	if s.Contains(value) {
+		return false, nil
+	}
+
+	(*set)(s).own()
+	s.dense[s.n] = value
+	s.sparse[value-s.offset] = s.n
+	s.n++
+
+	return true, nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *SparseSet) Remove(item int) {
+	if s.Contains(item) {
+		(*set)(s).own()
+		itemIndex := s.sparse[item-s.offset]
+		lastItem := s.dense[s.n-1]
+
+		s.dense[itemIndex] = lastItem
+		s.sparse[lastItem-s.offset] = itemIndex
+		s.n--
+		s.mods++
+	}
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering. With no intervening removals, this is the most recently
+// added member. Use PopRandom for a uniformly random member.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SparseSet) Pop() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	value := s.dense[s.n-1]
+	s.n--
+	s.mods++
+	return value, nil
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (s *SparseSet) Values() []int {
+	return s.dense[:s.n]
+}
@@ -1,6 +1,9 @@
 package intset
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -124,7 +127,7 @@ func TestGrowSetPop(t *testing.T) {
 
 	_, err = set.Pop()
 	assert(t, err != nil, "error should not be nil")
-	assert(t, err == EmptySetError, "error should be EmptySetError")
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
 }
 
 func TestShrinkSetContainsAndSize(t *testing.T) {
@@ -233,5 +236,417 @@ func TestShrinkSetPop(t *testing.T) {
 	}
 
 	_, err := set.Pop()
-	assert(t, err == EmptySetError, "error should be EmptySetError")
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestSparseSetAddAndRemove(t *testing.T) {
+	set := NewSparseSet(6)
+
+	set.Add(1)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	assert(t, set.Size() == 4, "set size should be 4")
+
+	set.Remove(3)
+	set.Remove(5)
+	set.Remove(0)
+
+	for _, v := range []int{0, 2, 3, 5, 6, -1} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	for _, v := range []int{1, 4} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	assert(t, set.Size() == 2, "set size should be 2")
+
+	set.Add(3)
+	assert(t, set.Contains(3), "set should contain 3")
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	err := set.Add(6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestSparseSetClearAndValues(t *testing.T) {
+	set := NewSparseSet(6)
+	set.Add(0)
+	set.Add(2)
+	set.Add(4)
+	set.Remove(2)
+
+	for _, v := range set.Values() {
+		assert(t, v == 0 || v == 4, "unknown value in set %v", v)
+	}
+
+	set.Clear()
+
+	assert(t, set.Size() == 0, "set size should be 0")
+	for i := 0; i < 6; i++ {
+		assert(t, !set.Contains(i), "set contains %v", i)
+	}
+}
+
+func TestSparseSetPop(t *testing.T) {
+	set := NewSparseSet(6)
+	set.Add(1)
+	set.Add(5)
+
+	seen := 0
+	for i := 0; i < 2; i++ {
+		popped, err := set.Pop()
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, popped == 1 || popped == 5, "popped value isn't correct")
+		assert(t, !set.Contains(popped), "set should not contain popped value")
+		seen += popped
+	}
+
+	assert(t, seen == 6, "duplicate popped value")
+
+	_, err := set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestShrinkSetAdd(t *testing.T) {
+	set := NewShrinkSet(6)
+
+	set.Remove(1)
+	set.Remove(3)
+	set.Remove(5)
+
+	set.Add(3)
+	set.Add(3)
+	set.Add(0)
+
+	for _, v := range []int{0, 2, 3, 4} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{1, 5} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	assert(t, set.Size() == 4, "set size should be 4")
+
+	err := set.Add(6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	set.Refill()
+	assert(t, set.Size() == 6, "set size should be 6")
+	for i := 0; i < 6; i++ {
+		assert(t, set.Contains(i), "set should contain %v", i)
+	}
+}
+
+func TestGrowSetFromBuffer(t *testing.T) {
+	buffer := []int{-7, 3, 1 << 40, 0, 5, 2, -1, 4, 1, 1, 3, 0}
+	set := NewGrowSetFromBuffer(buffer)
+
+	assert(t, set.Size() == 0, "set size should be 0")
+	for i := -1; i < 7; i++ {
+		assert(t, !set.Contains(i), "set should not contain %v", i)
+	}
+
+	set.Add(1)
+	set.Add(3)
+	set.Add(5)
+
+	for _, v := range []int{0, 2, 4, 6} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	for _, v := range []int{1, 3, 5} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	err := set.Add(6)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestWithBuffers(t *testing.T) {
+	sparse := []int{9, -3, 1 << 40, 2, 0}
+	dense := []int{4, 4, 4, 4, 4}
+
+	grow := NewGrowSetWithBuffers(sparse, dense)
+	assertMembers(t, grow.Contains, grow.Size(), 5)
+	grow.AddAll(0, 4)
+	assertMembers(t, grow.Contains, grow.Size(), 5, 0, 4)
+	assert(t, errors.Is(grow.Add(5), ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	sparse = []int{9, -3, 1 << 40, 2, 0}
+	dense = []int{4, 4, 4, 4, 4}
+	sparseSet := NewSparseSetWithBuffers(sparse, dense)
+	sparseSet.AddAll(1, 3)
+	sparseSet.Remove(1)
+	assertMembers(t, sparseSet.Contains, sparseSet.Size(), 5, 3)
+
+	sparse = []int{9, -3, 1 << 40, 2, 0}
+	dense = []int{4, 4, 4, 4, 4}
+	shrink := NewShrinkSetWithBuffers(sparse, dense)
+	assertMembers(t, shrink.Contains, shrink.Size(), 5, 0, 1, 2, 3, 4)
+	shrink.Remove(2)
+	assertMembers(t, shrink.Contains, shrink.Size(), 5, 0, 1, 3, 4)
+	assert(t, len(shrink.Values()) == 4, "values should have 4 members")
+
+	empty := NewShrinkSetWithBuffers(nil, nil)
+	assert(t, errors.Is(empty.Add(0), ErrValueOutOfRange), "empty set should be out of range, not uninitialized")
+
+	defer func() {
+		message, _ := recover().(string)
+		assert(t, strings.Contains(message, "differ in length"), "mismatched buffers should panic, got %q", message)
+	}()
+
+	NewGrowSetWithBuffers(make([]int, 3), make([]int, 4))
+}
+
+func TestShrinkSetLazy(t *testing.T) {
+	set := NewShrinkSet(8)
+	model := make(map[int]bool)
+	for i := 0; i < 8; i++ {
+		model[i] = true
+	}
+
+	check := func() {
+		assert(t, set.Size() == len(model), "set size should be %v", len(model))
+		for i := 0; i < 8; i++ {
+			assert(t, set.Contains(i) == model[i], "membership of %v should be %v", i, model[i])
+		}
+	}
+
+	for _, v := range []int{5, 0, 7, 3} {
+		set.Remove(v)
+		delete(model, v)
+		check()
+	}
+
+	set.Add(0)
+	model[0] = true
+	check()
+
+	popped, err := set.Pop()
+	assert(t, err == nil && model[popped], "invalid popped value %v", popped)
+	delete(model, popped)
+	check()
+
+	values := set.Values()
+	assert(t, len(values) == len(model), "values should have %v members", len(model))
+	for _, v := range values {
+		assert(t, model[v], "unexpected value %v", v)
+	}
+
+	removed := values[0]
+	set.Remove(removed)
+	delete(model, removed)
+	check()
+}
+
+func TestNewGrowSetFromSlice(t *testing.T) {
+	set, err := NewGrowSetFromSlice(6, []int{4, 1, 4, 0, 1})
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	for _, v := range []int{0, 1, 4} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{2, 3, 5} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	set, err = NewGrowSetFromSlice(6, []int{1, 6})
+	assert(t, set == nil && errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	_, err = NewGrowSetFromSlice(6, []int{-1})
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestRangeConstructors(t *testing.T) {
+	g := NewGrowSetRange(-100, 101)
+	g.Add(-100)
+	g.Add(0)
+	g.Add(100)
+
+	err := g.Add(101)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	err = g.Add(-101)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	assert(t, len(g.sparse) == 201, "set should only allocate its range")
+	assertMembers(t, g.Contains, g.Size(), 0, -100, 0, 100)
+	for _, v := range []int{-101, -99, 1, 101} {
+		assert(t, !g.Contains(v), "set should not contain %v", v)
+	}
+
+	s := NewShrinkSetRange(1e9, 1e9+5)
+	assert(t, s.Size() == 5, "set size should be 5")
+	s.Remove(1e9 + 2)
+	assert(t, !s.Contains(1e9+2) && s.Contains(1e9+4), "remove should use the range")
+	assert(t, !s.Contains(2), "set should not contain 2")
+
+	min, _ := s.Min()
+	assert(t, min == 1e9, "min should be 1e9, is %v", min)
+	for _, v := range s.Values() {
+		assert(t, v >= 1e9 && v < 1e9+5 && v != 1e9+2, "unexpected value %v", v)
+	}
+
+	p := NewSparseSetRange(-3, 3)
+	p.Add(-3)
+	p.Add(2)
+	p.Remove(-3)
+	assert(t, p.Size() == 1 && p.Contains(2) && !p.Contains(-3), "sparse set should use the range")
+}
+
+func TestCapacity(t *testing.T) {
+	assert(t, NewGrowSet(6).Capacity() == 6, "GrowSet capacity should be 6")
+	assert(t, NewShrinkSet(7).Capacity() == 7, "ShrinkSet capacity should be 7")
+	assert(t, NewSparseSet(8).Capacity() == 8, "SparseSet capacity should be 8")
+	assert(t, NewGrowSetRange(-5, 5).Capacity() == 10, "range capacity should be 10")
+	assert(t, NewAtomicGrowSet(9).Capacity() == 9, "AtomicGrowSet capacity should be 9")
+	assert(t, NewShardedSet(10, 3).Capacity() == 10, "ShardedSet capacity should be 10")
+
+	d := NewDynamicSet(2)
+	d.Add(10)
+	assert(t, d.Capacity() >= 11, "DynamicSet capacity should have grown")
+}
+
+func TestShrinkSetRemovedValues(t *testing.T) {
+	set := NewShrinkSetRange(-2, 4)
+	assert(t, len(set.RemovedValues()) == 0, "nothing should have been removed")
+
+	set.Remove(-1)
+	set.Remove(3)
+	removed := slices.Clone(set.RemovedValues())
+	slices.Sort(removed)
+	assert(t, slices.Equal(removed, []int{-1, 3}), "removed values should be [-1 3], got %v", removed)
+
+	set.Add(3)
+	set.Invert()
+	removed = slices.Clone(set.RemovedValues())
+	slices.Sort(removed)
+	assert(t, slices.Equal(removed, []int{-2, 0, 1, 2, 3}), "removed values should be the old members, got %v", removed)
+}
+
+func TestAddReported(t *testing.T) {
+	g := NewGrowSet(5)
+	added, err := g.AddReported(3)
+	assert(t, added && err == nil, "3 should have been added")
+	added, err = g.AddReported(3)
+	assert(t, !added && err == nil, "3 should already be a member")
+	added, err = g.AddReported(5)
+	assert(t, !added && errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	s := NewShrinkSet(5)
+	added, _ = s.AddReported(1)
+	assert(t, !added, "1 should already be a member")
+	s.Remove(1)
+	added, _ = s.AddReported(1)
+	assert(t, added && s.Contains(1), "1 should have been added back")
+
+	p := NewSparseSet(5)
+	added, _ = p.AddReported(4)
+	assert(t, added, "4 should have been added")
+	added, _ = p.AddReported(4)
+	assert(t, !added, "4 should already be a member")
+
+	d := NewDynamicSet(1)
+	added, _ = d.AddReported(100)
+	assert(t, added && d.Contains(100), "100 should have been added")
+	added, err = d.AddReported(-1)
+	assert(t, !added && errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestInvalidCapacity(t *testing.T) {
+	constructors := map[string]func(){
+		"NewGrowSet":          func() { NewGrowSet(-1) },
+		"NewShrinkSet":        func() { NewShrinkSet(-1) },
+		"NewSparseSet":        func() { NewSparseSet(-1) },
+		"NewGrowSetRange":     func() { NewGrowSetRange(5, 4) },
+		"NewShrinkSetRange":   func() { NewShrinkSetRange(5, 4) },
+		"NewSparseSetRange":   func() { NewSparseSetRange(5, 4) },
+		"NewDynamicSet":       func() { NewDynamicSet(-1) },
+		"NewAtomicGrowSet":    func() { NewAtomicGrowSet(-1) },
+		"NewShardedSet":       func() { NewShardedSet(-1, 2) },
+		"NewBitSet":           func() { NewBitSet(-1) },
+		"NewCompactGrowSet":   func() { NewCompactGrowSet[uint16](-1) },
+		"NewSparseMap":        func() { NewSparseMap[int](-1) },
+		"NewDisjointSets":     func() { NewDisjointSets(-1) },
+		"NewGrowSetFromSlice": func() { NewGrowSetFromSlice(-1, nil) },
+	}
+
+	for name, constructor := range constructors {
+		func() {
+			defer func() {
+				message, _ := recover().(string)
+				assert(t, strings.HasPrefix(message, "intset: "), "%v should panic with a clear message, got %q", name, message)
+			}()
+
+			constructor()
+		}()
+	}
+}
+
+func TestZeroCapacity(t *testing.T) {
+	sets := map[string]interface {
+		IntSet
+		Add(int) error
+	}{
+		"GrowSet":       NewGrowSet(0),
+		"ShrinkSet":     NewShrinkSet(0),
+		"SparseSet":     NewSparseSet(0),
+		"AtomicGrowSet": NewAtomicGrowSet(0),
+		"ShardedSet":    NewShardedSet(0, 2),
+		"BitSet":        NewBitSet(0),
+	}
+
+	for name, set := range sets {
+		assert(t, set.Size() == 0, "%v should be empty", name)
+		assert(t, len(set.Values()) == 0, "%v should have no values", name)
+		assert(t, !set.Contains(0), "%v should not contain 0", name)
+		assert(t, errors.Is(set.Add(0), ErrValueOutOfRange), "%v should reject 0", name)
+
+		_, err := set.Pop()
+		assert(t, err == ErrEmptySet, "%v Pop should return ErrEmptySet", name)
+	}
+}
+
+func TestRangeError(t *testing.T) {
+	set := NewSparseSetRange(-5, 5)
+	err := set.Add(7)
+
+	var rangeErr *RangeError
+	assert(t, errors.As(err, &rangeErr), "error should be a RangeError, got %v", err)
+	assert(t, rangeErr.Value == 7 && rangeErr.Min == -5 && rangeErr.Max == 5, "wrong error %+v", rangeErr)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should match ErrValueOutOfRange")
+	assert(t, err.Error() == "value 7 out of range [-5, 5)", "wrong message %q", err.Error())
+
+	err = NewGrowSet(10).AddRange(8, 12)
+	assert(t, errors.As(err, &rangeErr) && rangeErr.Value == 11, "AddRange should report 11, got %v", err)
+
+	assert(t, errors.Is(err, ValueOutOfRangeError), "old name should still match")
+	assert(t, EmptySetError == ErrEmptySet, "old names should be aliases")
+}
+
+func TestZeroValue(t *testing.T) {
+	var grow GrowSet
+	var shrink ShrinkSet
+	var sparse SparseSet
+
+	assert(t, grow.Add(0) == ErrUninitialized, "GrowSet should be uninitialized")
+	assert(t, shrink.Add(0) == ErrUninitialized, "ShrinkSet should be uninitialized")
+	assert(t, sparse.Add(0) == ErrUninitialized, "SparseSet should be uninitialized")
+	assert(t, sparse.AddAll(1, 2) == ErrUninitialized, "AddAll should report uninitialized")
+	assert(t, sparse.AddRange(1, 2) == ErrUninitialized, "AddRange should report uninitialized")
+	assert(t, grow.Size() == 0 && !grow.Contains(0), "zero value should be empty")
+
+	_, err := shrink.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	assert(t, errors.Is(NewGrowSet(0).Add(0), ErrValueOutOfRange), "constructed empty set should be out of range")
+	assert(t, errors.Is(NewGrowSetFromBuffer(nil).Add(0), ErrValueOutOfRange), "empty buffer set should be out of range")
+
+	var dynamic DynamicSet
+	assert(t, dynamic.Add(5) == nil && dynamic.Contains(5), "DynamicSet zero value should be usable")
 }
@@ -0,0 +1,118 @@
+package intset
+
+import (
+	"fmt"
+)
+
+// Returns an error wrapping ErrCorrupt that describes an inconsistency.
+func corrupt(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrCorrupt}, args...)...)
+}
+
+// Checks that the arrays of s are the same length and that its size
+// fits in them.
+func (s *set) checkShape() error {
+	if len(s.sparse) != len(s.dense) {
+		return corrupt("sparse and dense arrays differ in length (%d and %d)", len(s.sparse), len(s.dense))
+	}
+
+	if s.n < 0 || s.n > len(s.dense) {
+		return corrupt("size %d out of range [0, %d]", s.n, len(s.dense))
+	}
+
+	return nil
+}
+
+// Checks that each member of s, which must not be a ShrinkSet, is in
+// range and is pointed back to by its sparse entry, which also ensures
+// no member appears twice.
+func (s *set) checkInvariants() error {
+	if err := s.checkShape(); err != nil {
+		return err
+	}
+
+	for i, v := range s.dense[:s.n] {
+		if !s.inRange(v) {
+			lo, hi := s.bounds()
+			return corrupt("member %d at index %d out of range [%d, %d)", v, i, lo, hi)
+		}
+
+		if index := s.sparse[v-s.offset]; index != i {
+			return corrupt("member %d at index %d has sparse entry %d", v, i, index)
+		}
+	}
+
+	return nil
+}
+
+// Verifies that the internal state of the set is consistent: that its
+// arrays agree with each other, that its size fits in them, and that its
+// record of its smallest and largest members is correct. This takes
+// O(k) time, where k is the size of the set, and is meant for tests and
+// for checking sets after they have been decoded or mapped from
+// untrusted storage.
+// If the set is inconsistent, an error wrapping ErrCorrupt that
+// describes the first inconsistency found is returned, otherwise nil.
+func (g *GrowSet) CheckInvariants() error {
+	if err := (*set)(g).checkInvariants(); err != nil {
+		return err
+	}
+
+	if g.stale || g.n == 0 {
+		return nil
+	}
+
+	lo, hi := g.dense[0], g.dense[0]
+	for _, v := range g.dense[:g.n] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	if g.min != lo || g.max != hi {
+		return corrupt("recorded bounds [%d, %d] differ from members' [%d, %d]", g.min, g.max, lo, hi)
+	}
+
+	return nil
+}
+
+// Verifies that the internal state of the set is consistent: that its
+// arrays agree with each other and form a permutation of the values it
+// can hold, that its size fits in them, and that its record of the
+// bounds of its members encloses them. Unlike the other checks, this
+// takes O(n) time, where n == capacity, since the removed values must be
+// checked as well as the members.
+// If the set is inconsistent, an error wrapping ErrCorrupt that
+// describes the first inconsistency found is returned, otherwise nil.
+func (s *ShrinkSet) CheckInvariants() error {
+	if err := (*set)(s).checkShape(); err != nil {
+		return err
+	}
+
+	for i := range s.dense {
+		v := s.at(i)
+		if !(*set)(s).inRange(v) {
+			lo, hi := (*set)(s).bounds()
+			return corrupt("value %d at index %d out of range [%d, %d)", v, i, lo, hi)
+		}
+
+		if index := s.indexOf(v); index != i {
+			return corrupt("value %d at index %d has sparse entry %d", v, i, index)
+		}
+
+		if i < s.n && (v < s.min || v > s.max) {
+			return corrupt("member %d outside recorded bounds [%d, %d]", v, s.min, s.max)
+		}
+	}
+
+	return nil
+}
+
+// Verifies that the internal state of the set is consistent: that its
+// arrays agree with each other and that its size fits in them. This
+// takes O(k) time, where k is the size of the set, and is meant for
+// tests and for checking sets after they have been decoded or mapped
+// from untrusted storage.
+// If the set is inconsistent, an error wrapping ErrCorrupt that
+// describes the first inconsistency found is returned, otherwise nil.
+func (s *SparseSet) CheckInvariants() error {
+	return (*set)(s).checkInvariants()
+}
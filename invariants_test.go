@@ -0,0 +1,70 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	grow := NewGrowSetRange(-20, 80)
+	shrink := NewShrinkSetRange(-20, 80)
+	sparse := NewSparseSetRange(-20, 80)
+	for i := 0; i < 2000; i++ {
+		v := rand.Intn(100) - 20
+		switch rand.Intn(6) {
+		case 0:
+			grow.Pop()
+			shrink.Add(v)
+		case 1:
+			grow.RetainIf(func(m int) bool { return m != v })
+			shrink.Invert()
+		case 2:
+			sparse.Remove(v)
+			shrink.PopMin()
+		default:
+			grow.Add(v)
+			shrink.Remove(v)
+			sparse.Add(v)
+		}
+
+		grow.Min()
+		assert(t, grow.CheckInvariants() == nil, "GrowSet: %v", grow.CheckInvariants())
+		assert(t, shrink.CheckInvariants() == nil, "ShrinkSet: %v", shrink.CheckInvariants())
+		assert(t, sparse.CheckInvariants() == nil, "SparseSet: %v", sparse.CheckInvariants())
+	}
+
+	var zero GrowSet
+	assert(t, zero.CheckInvariants() == nil, "the zero value should be consistent")
+}
+
+func TestCheckInvariantsCorrupt(t *testing.T) {
+	sparse := NewSparseSet(10)
+	sparse.AddAll(1, 2, 3)
+	sparse.sparse[2] = 0
+	err := sparse.CheckInvariants()
+	assert(t, errors.Is(err, ErrCorrupt), "error should be ErrCorrupt, is %v", err)
+	assert(t, err.Error() == "set corrupt: member 2 at index 1 has sparse entry 0", "error is %q", err)
+
+	sparse = NewSparseSet(10)
+	sparse.n = 11
+	assert(t, errors.Is(sparse.CheckInvariants(), ErrCorrupt), "size too large should be detected")
+
+	grow := growSetOf(10, 4, 5)
+	grow.dense[1] = 12
+	assert(t, errors.Is(grow.CheckInvariants(), ErrCorrupt), "out of range member should be detected")
+
+	grow = growSetOf(10, 4, 5)
+	grow.min = 3
+	assert(t, errors.Is(grow.CheckInvariants(), ErrCorrupt), "wrong bounds should be detected")
+
+	shrink := NewShrinkSet(10)
+	shrink.Remove(3)
+	shrink.dense[0] = 3
+	assert(t, errors.Is(shrink.CheckInvariants(), ErrCorrupt), "duplicate value should be detected")
+
+	shrink = NewShrinkSet(10)
+	shrink.Remove(9)
+	shrink.max = 5
+	assert(t, errors.Is(shrink.CheckInvariants(), ErrCorrupt), "member outside bounds should be detected")
+}
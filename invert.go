@@ -0,0 +1,82 @@
+package intset
+
+// Replaces the members of s with the values it can hold that are not
+// members, without allocating. The complement is built in the unused
+// part of the dense array, where it cannot disturb the members, and
+// then moved to the front. This takes O(n) time, where n == capacity.
+func (s *set) invert() {
+	s.own()
+	s.mods += uint(s.n) + 1
+	k := s.n
+	lowest, limit := s.bounds()
+	for v := lowest; v < limit; v++ {
+		index := s.sparse[v-s.offset]
+		if index < 0 || index >= s.n || s.dense[index] != v {
+			s.dense[k] = v
+			s.sparse[v-s.offset] = k
+			k++
+		}
+	}
+
+	copy(s.dense, s.dense[s.n:k])
+	s.n = k - s.n
+	for i, v := range s.dense[:s.n] {
+		s.sparse[v-s.offset] = i
+	}
+
+	s.stale = true
+}
+
+// Replaces the members of the set with the values it can hold that are
+// not members, without allocating.
+// Unlike a ShrinkSet, a GrowSet does not keep track of its non-members,
+// so this takes O(n) time, where n == capacity.
+func (g *GrowSet) Invert() {
+	(*set)(g).invert()
+}
+
+// Replaces the members of the set with the values it can hold that are
+// not members, in O(1) time.
+// The members of a ShrinkSet occupy the front of its dense array and the
+// values removed from it occupy the rest, so inverting the set only
+// requires using the array back to front, with the removed values now
+// at its front.
+func (s *ShrinkSet) Invert() {
+	s.inverted = !s.inverted
+	s.mods += uint(s.n) + 1
+	s.n = len(s.dense) - s.n
+	s.min = s.offset
+	s.max = s.offset + len(s.dense) - 1
+}
+
+// Replaces the members of the set with the values it can hold that are
+// not members, without allocating.
+// Unlike a ShrinkSet, a SparseSet does not keep track of its
+// non-members, so this takes O(n) time, where n == capacity.
+func (s *SparseSet) Invert() {
+	(*set)(s).invert()
+}
+
+// Returns a new set holding the values the set can hold that are not
+// members of it. This takes O(n) time, where n == capacity.
+func (g *GrowSet) Complement() *GrowSet {
+	result := g.Clone()
+	result.Invert()
+	return result
+}
+
+// Returns a new set holding the values the set can hold that are not
+// members of it. This takes O(n) time, where n == capacity.
+func (s *ShrinkSet) Complement() *ShrinkSet {
+	result := s.Clone()
+	result.Invert()
+	return result
+}
+
+// Returns a new set holding the values the set can hold that are not
+// members of it. This takes O(n) time, where n == capacity.
+func (s *SparseSet) Complement() *SparseSet {
+	result := s.Clone()
+	result.Invert()
+	return result
+}
@@ -0,0 +1,81 @@
+package intset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrowSetInvert(t *testing.T) {
+	g := growSetOf(6, 1, 4)
+	g.Invert()
+	assertMembers(t, g.Contains, g.Size(), 6, 0, 2, 3, 5)
+
+	min, _ := g.Min()
+	max, _ := g.Max()
+	assert(t, min == 0 && max == 5, "extremes should be recomputed")
+
+	g.Invert()
+	assertMembers(t, g.Contains, g.Size(), 6, 1, 4)
+
+	r := NewGrowSetRange(-2, 2)
+	r.Invert()
+	assertMembers(t, r.Contains, r.Size(), 2, -2, -1, 0, 1)
+}
+
+func TestShrinkSetInvert(t *testing.T) {
+	s := NewShrinkSet(6)
+	s.Remove(1)
+	s.Remove(4)
+
+	s.Invert()
+	assertMembers(t, s.Contains, s.Size(), 6, 1, 4)
+
+	values := slices.Clone(s.Values())
+	slices.Sort(values)
+	assert(t, slices.Equal(values, []int{1, 4}), "values should be [1 4], got %v", values)
+
+	s.Remove(4)
+	s.Add(2)
+	assertMembers(t, s.Contains, s.Size(), 6, 1, 2)
+
+	min, _ := s.Min()
+	assert(t, min == 1, "min should be 1")
+
+	s.Invert()
+	assertMembers(t, s.Contains, s.Size(), 6, 0, 3, 4, 5)
+
+	s.Grow(8)
+	assertMembers(t, s.Contains, s.Size(), 8, 0, 3, 4, 5, 6, 7)
+
+	s.Refill()
+	assert(t, s.Size() == 8, "set size should be 8")
+}
+
+func TestSparseSetInvert(t *testing.T) {
+	s := NewSparseSet(5)
+	s.Invert()
+	assertMembers(t, s.Contains, s.Size(), 5, 0, 1, 2, 3, 4)
+
+	s.Remove(2)
+	s.Invert()
+	assertMembers(t, s.Contains, s.Size(), 5, 2)
+}
+
+func TestComplement(t *testing.T) {
+	g := growSetOf(5, 0, 3)
+	c := g.Complement()
+	assertMembers(t, c.Contains, c.Size(), 5, 1, 2, 4)
+	assertMembers(t, g.Contains, g.Size(), 5, 0, 3)
+
+	s := NewShrinkSet(4)
+	s.Remove(2)
+	sc := s.Complement()
+	assertMembers(t, sc.Contains, sc.Size(), 4, 2)
+	assertMembers(t, s.Contains, s.Size(), 4, 0, 1, 3)
+
+	p := NewSparseSet(3)
+	p.Add(1)
+	pc := p.Complement()
+	assertMembers(t, pc.Contains, pc.Size(), 3, 0, 2)
+	assertMembers(t, p.Contains, p.Size(), 3, 1)
+}
@@ -0,0 +1,144 @@
+package intset
+
+import (
+	"iter"
+	"slices"
+)
+
+// Panics unless the only changes made to s since mods was recorded,
+// while visiting a member, were adding members and removing the member
+// being visited, which contained reports whether it still is.
+func (s *set) checkIteration(mods uint, contained bool) {
+	if s.mods != mods+1 || contained {
+		panic("intset: set modified during iteration")
+	}
+}
+
+// Returns an iterator over the members of the set.
+// Members are visited from the end of the dense array backwards, so
+// the set may safely be added to during iteration; members added
+// during iteration are not visited. Any other change made during
+// iteration, such as popping a member that has not yet been visited,
+// causes the iterator to panic rather than visit members twice or not
+// at all.
+// Iteration does not allocate.
+func (g *GrowSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := g.n - 1; i >= 0; i-- {
+			value, mods := g.dense[i], g.mods
+			if !yield(value) {
+				return
+			}
+
+			if g.mods != mods {
+				(*set)(g).checkIteration(mods, g.Contains(value))
+			}
+		}
+	}
+}
+
+// Returns an iterator over the members of the set.
+// Members are visited from the end of the dense array backwards, so
+// the member being visited may safely be removed during iteration, and
+// members added during iteration are not visited. Any other change made
+// during iteration, such as removing a member other than the one being
+// visited, causes the iterator to panic rather than visit members twice
+// or not at all.
+// Iteration does not allocate.
+func (s *ShrinkSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := s.n - 1; i >= 0; i-- {
+			value, mods := s.at(i), s.mods
+			if !yield(value) {
+				return
+			}
+
+			if s.mods != mods {
+				(*set)(s).checkIteration(mods, s.Contains(value))
+			}
+		}
+	}
+}
+
+// Returns an iterator over the members of the set.
+// Members are visited from the end of the dense array backwards, so
+// the member being visited may safely be removed during iteration, and
+// members added during iteration are not visited. Any other change made
+// during iteration, such as removing a member other than the one being
+// visited, causes the iterator to panic rather than visit members twice
+// or not at all.
+// Iteration does not allocate.
+func (s *SparseSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := s.n - 1; i >= 0; i-- {
+			value, mods := s.dense[i], s.mods
+			if !yield(value) {
+				return
+			}
+
+			if s.mods != mods {
+				(*set)(s).checkIteration(mods, s.Contains(value))
+			}
+		}
+	}
+}
+
+// Returns an iterator over the members of the set, most recently added
+// first. This is the order in which All visits them, and the reverse of
+// Values; Backward is provided so that code relying on the order can
+// say so. It is otherwise the same as All.
+func (g *GrowSet) Backward() iter.Seq[int] {
+	return g.All()
+}
+
+// Returns an iterator over the members of the set, from the end of the
+// dense array to its start, so a newly allocated or refilled set is
+// visited from its largest member down.
+// It is otherwise the same as All.
+func (s *ShrinkSet) Backward() iter.Seq[int] {
+	return s.All()
+}
+
+// Returns an iterator over the members of the set, from the end of the
+// dense array to its start. Since Remove moves the last member of the
+// dense array into the gap it leaves, this is only most recently added
+// first if no member has been removed since the set was last cleared.
+// It is otherwise the same as All.
+func (s *SparseSet) Backward() iter.Seq[int] {
+	return s.All()
+}
+
+// Copies values into dst[:0] and sorts them in increasing order,
+// returning the result.
+func sortedInto(dst []int, values []int) []int {
+	dst = append(dst[:0], values...)
+	slices.Sort(dst)
+	return dst
+}
+
+// Returns the members of the set in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(k log k) time, where k is the size of the set.
+func (g *GrowSet) SortedValues(dst []int) []int {
+	return sortedInto(dst, g.Values())
+}
+
+// Returns the members of the set in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(k log k) time, where k is the size of the set.
+func (s *ShrinkSet) SortedValues(dst []int) []int {
+	return sortedInto(dst, s.Values())
+}
+
+// Returns the members of the set in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(k log k) time, where k is the size of the set.
+func (s *SparseSet) SortedValues(dst []int) []int {
+	return sortedInto(dst, s.Values())
+}
@@ -0,0 +1,198 @@
+package intset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrowSetAll(t *testing.T) {
+	set := growSetOf(10, 1, 4, 7)
+
+	seen := make(map[int]bool)
+	for v := range set.All() {
+		seen[v] = true
+		set.Add(v + 1)
+	}
+
+	assert(t, len(seen) == 3, "should have visited 3 members, visited %v", len(seen))
+	for _, v := range []int{1, 4, 7} {
+		assert(t, seen[v], "should have visited %v", v)
+	}
+
+	for range set.All() {
+		break
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		for range set.All() {
+		}
+	})
+	assert(t, allocs == 0, "iteration should not allocate")
+}
+
+func TestShrinkSetAll(t *testing.T) {
+	set := NewShrinkSet(6)
+	set.Remove(2)
+
+	seen := make(map[int]bool)
+	for v := range set.All() {
+		seen[v] = true
+		if v%2 == 1 {
+			set.Remove(v)
+		}
+	}
+
+	assert(t, len(seen) == 5, "should have visited 5 members, visited %v", len(seen))
+	assert(t, !seen[2], "should not have visited 2")
+	assert(t, set.Size() == 2, "set size should be 2")
+	assert(t, set.Contains(0) && set.Contains(4), "set should contain 0 and 4")
+}
+
+func TestSparseSetAll(t *testing.T) {
+	set := NewSparseSet(10)
+	for _, v := range []int{0, 3, 5, 8} {
+		set.Add(v)
+	}
+
+	seen := make(map[int]bool)
+	for v := range set.All() {
+		seen[v] = true
+		set.Remove(v)
+	}
+
+	assert(t, len(seen) == 4, "should have visited 4 members, visited %v", len(seen))
+	assert(t, set.Size() == 0, "set size should be 0")
+}
+
+// Returns the message that f panics with, or "" if it does not panic.
+func panicMessage(f func()) (message string) {
+	defer func() {
+		message, _ = recover().(string)
+	}()
+
+	f()
+	return ""
+}
+
+func TestAllModifiedDuringIteration(t *testing.T) {
+	sparse := NewSparseSet(10)
+	sparse.AddAll(1, 2, 3, 4)
+	message := panicMessage(func() {
+		for v := range sparse.All() {
+			if v == 4 {
+				sparse.Remove(1)
+			}
+		}
+	})
+	assert(t, message == "intset: set modified during iteration", "removing another member should panic, got %q", message)
+
+	sparse = NewSparseSet(10)
+	sparse.AddAll(1, 2, 3, 4)
+	seen := 0
+	message = panicMessage(func() {
+		for v := range sparse.All() {
+			seen++
+			sparse.Remove(v)
+			sparse.Add(v + 5)
+		}
+	})
+	assert(t, message == "" && seen == 4, "removing the current member and adding should be allowed, got %q", message)
+
+	grow := growSetOf(10, 1, 2, 3)
+	message = panicMessage(func() {
+		for v := range grow.All() {
+			if v == 2 {
+				grow.Pop()
+			}
+		}
+	})
+	assert(t, message != "", "popping an unvisited member should panic")
+
+	grow = growSetOf(10, 1, 2, 3)
+	message = panicMessage(func() {
+		for range grow.All() {
+			grow.Clear()
+		}
+	})
+	assert(t, message != "", "clearing should panic")
+
+	shrink := NewShrinkSet(5)
+	message = panicMessage(func() {
+		for range shrink.All() {
+			shrink.Pop()
+		}
+	})
+	assert(t, message != "", "popping from the front should panic")
+
+	shrink = NewShrinkSet(5)
+	message = panicMessage(func() {
+		for v := range shrink.All() {
+			if v == 3 {
+				shrink.Invert()
+			}
+		}
+	})
+	assert(t, message != "", "inverting should panic")
+
+	shrink = NewShrinkSet(5)
+	shrink.Remove(0)
+	message = panicMessage(func() {
+		for range shrink.All() {
+			shrink.Refill()
+		}
+	})
+	assert(t, message == "", "refilling should be allowed, got %q", message)
+}
+
+func TestBackward(t *testing.T) {
+	g := growSetOf(10, 3, 8, 1)
+	assert(t, slices.Equal(slices.Collect(g.Backward()), []int{1, 8, 3}), "GrowSet should be visited most recently added first")
+
+	s := NewShrinkSet(4)
+	assert(t, slices.Equal(slices.Collect(s.Backward()), []int{3, 2, 1, 0}), "ShrinkSet should be visited from its largest member down")
+
+	sparse := NewSparseSet(10)
+	for _, v := range []int{5, 0, 9} {
+		sparse.Add(v)
+	}
+	assert(t, slices.Equal(slices.Collect(sparse.Backward()), []int{9, 0, 5}), "SparseSet should be visited most recently added first")
+
+	o := NewOrderedSet(5)
+	for _, v := range []int{1, 2, 3, 4} {
+		o.Add(v)
+	}
+	o.Pop()
+	o.Pop()
+	o.Add(0)
+	o.Add(1)
+	assert(t, slices.Equal(slices.Collect(o.Backward()), []int{1, 0, 4, 3}), "OrderedSet should be visited most recently added first, not %v", slices.Collect(o.Backward()))
+
+	for range o.Backward() {
+		break
+	}
+}
+
+func TestSortedValues(t *testing.T) {
+	g := growSetOf(10, 7, 1, 4, 0)
+	sorted := g.SortedValues(nil)
+	assert(t, slices.Equal(sorted, []int{0, 1, 4, 7}), "sorted values are %v", sorted)
+
+	s := NewShrinkSet(6)
+	s.Remove(0)
+	s.Remove(3)
+	buffer := make([]int, 0, 6)
+	sorted = s.SortedValues(buffer)
+	assert(t, slices.Equal(sorted, []int{1, 2, 4, 5}), "sorted values are %v", sorted)
+	assert(t, &sorted[0] == &buffer[:1][0], "buffer should have been reused")
+
+	allocs := testing.AllocsPerRun(10, func() {
+		s.SortedValues(buffer)
+	})
+	assert(t, allocs == 0, "sorting into a large enough buffer should not allocate")
+
+	p := NewSparseSet(10)
+	p.Add(9)
+	p.Add(2)
+	sorted = p.SortedValues(nil)
+	assert(t, slices.Equal(sorted, []int{2, 9}), "sorted values are %v", sorted)
+}
@@ -0,0 +1,105 @@
+package intset
+
+import (
+	"encoding/json"
+)
+
+// The JSON encoding of a set is an object holding the capacity of the
+// set and an array of its members, in the order of the set's dense
+// array. If the smallest value the set can hold is not zero, it is
+// included as the offset:
+//
+//   {"capacity": 10, "members": [3, 1, 4]}
+//   {"offset": -5, "capacity": 10, "members": [-3, 1, 4]}
+type jsonSet struct {
+	Offset   int   `json:"offset,omitempty"`
+	Capacity int   `json:"capacity"`
+	Members  []int `json:"members"`
+}
+
+// Returns the JSON encoding of a set.
+func marshalJSON(offset int, capacity int, values []int) ([]byte, error) {
+	if values == nil {
+		values = []int{}
+	}
+
+	return json.Marshal(jsonSet{Offset: offset, Capacity: capacity, Members: values})
+}
+
+// Decodes the JSON encoding of a set from data, calling create with
+// the smallest value and the capacity of the set and then add with
+// each member.
+// If the members do not fit in the set, or the set would have a
+// capacity greater than MaxDecodedCapacity or values beyond the largest
+// int, ErrInvalidEncoding is returned.
+func unmarshalJSON(data []byte, create func(offset, capacity int), add func(value int) error) error {
+	var decoded jsonSet
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if decoded.Capacity < 0 || decoded.Capacity > MaxDecodedCapacity || len(decoded.Members) > decoded.Capacity {
+		return ErrInvalidEncoding
+	}
+
+	if decoded.Offset+decoded.Capacity < decoded.Offset {
+		return ErrInvalidEncoding
+	}
+
+	create(decoded.Offset, decoded.Capacity)
+	for _, v := range decoded.Members {
+		if add(v) != nil {
+			return ErrInvalidEncoding
+		}
+	}
+
+	return nil
+}
+
+// Returns the JSON encoding of the set, implementing json.Marshaler.
+func (g *GrowSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(g.offset, len(g.sparse), g.Values())
+}
+
+// Replaces the set with one decoded from JSON, implementing
+// json.Unmarshaler.
+// If the members do not fit in the capacity, or the capacity is greater
+// than MaxDecodedCapacity, ErrInvalidEncoding is returned and the set is
+// left in an unspecified state.
+func (g *GrowSet) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, func(offset, capacity int) { *g = *NewGrowSetRange(offset, offset+capacity) }, g.Add)
+}
+
+// Returns the JSON encoding of the set, implementing json.Marshaler.
+func (s *ShrinkSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.offset, len(s.sparse), s.Values())
+}
+
+// Replaces the set with one decoded from JSON, implementing
+// json.Unmarshaler. Refilling the decoded set restores every value
+// less than its capacity.
+// If the members do not fit in the capacity, or the capacity is greater
+// than MaxDecodedCapacity, ErrInvalidEncoding is returned and the set is
+// left in an unspecified state.
+func (s *ShrinkSet) UnmarshalJSON(data []byte) error {
+	create := func(offset, capacity int) {
+		*s = *NewShrinkSetRange(offset, offset+capacity)
+		s.n = 0
+	}
+
+	return unmarshalJSON(data, create, s.Add)
+}
+
+// Returns the JSON encoding of the set, implementing json.Marshaler.
+func (s *SparseSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s.offset, len(s.sparse), s.Values())
+}
+
+// Replaces the set with one decoded from JSON, implementing
+// json.Unmarshaler.
+// If the members do not fit in the capacity, or the capacity is greater
+// than MaxDecodedCapacity, ErrInvalidEncoding is returned and the set is
+// left in an unspecified state.
+func (s *SparseSet) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(data, func(offset, capacity int) { *s = *NewSparseSetRange(offset, offset+capacity) }, s.Add)
+}
@@ -0,0 +1,67 @@
+package intset
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestGrowSetJSON(t *testing.T) {
+	set := growSetOf(10, 3, 1, 4)
+	data, err := json.Marshal(set)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, string(data) == `{"capacity":10,"members":[3,1,4]}`, "encoded as %s", data)
+
+	var decoded GrowSet
+	err = json.Unmarshal(data, &decoded)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, slices.Equal(decoded.Values(), set.Values()), "decoded values are %v", decoded.Values())
+
+	data, _ = json.Marshal(NewGrowSet(2))
+	assert(t, string(data) == `{"capacity":2,"members":[]}`, "encoded as %s", data)
+}
+
+func TestShrinkSetJSON(t *testing.T) {
+	var config struct {
+		Free *ShrinkSet `json:"free"`
+	}
+
+	err := json.Unmarshal([]byte(`{"free": {"capacity": 5, "members": [4, 0]}}`), &config)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, config.Free.Contains, config.Free.Size(), 5, 0, 4)
+
+	data, _ := json.Marshal(config)
+	assert(t, string(data) == `{"free":{"capacity":5,"members":[4,0]}}`, "encoded as %s", data)
+}
+
+func TestSparseSetJSON(t *testing.T) {
+	var set SparseSet
+	err := json.Unmarshal([]byte(`{"capacity": 3, "members": [2]}`), &set)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 3, 2)
+
+	for _, data := range []string{
+		`{"capacity": 3, "members": [3]}`,
+		`{"capacity": 3, "members": [-1]}`,
+		`{"capacity": -1, "members": []}`,
+		`{"capacity": 1, "members": [0, 0]}`,
+		`{"offset": 1, "capacity": 9223372036854775807, "members": []}`,
+		`{"capacity": 4611686018427387904, "members": []}`,
+		`{"offset": 9223372036854775800, "capacity": 10, "members": []}`,
+	} {
+		err := json.Unmarshal([]byte(data), &set)
+		assert(t, err == ErrInvalidEncoding, "decoding %v should fail, got %v", data, err)
+	}
+}
+
+func TestJSONWithOffset(t *testing.T) {
+	set := NewSparseSetRange(-5, 5)
+	set.Add(-3)
+	data, _ := json.Marshal(set)
+	assert(t, string(data) == `{"offset":-5,"capacity":10,"members":[-3]}`, "encoded as %s", data)
+
+	var decoded SparseSet
+	err := json.Unmarshal(data, &decoded)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, decoded.Contains(-3) && decoded.Size() == 1, "decoded set differs")
+}
@@ -0,0 +1,180 @@
+package intset
+
+import (
+	"iter"
+)
+
+// An LRUSet is a set that keeps its members in the order they were last
+// used, so that the least recently used member can be found and evicted.
+// Members are linked into a list from oldest to newest through arrays
+// indexed by value, so it supports the following operations in O(1)
+// time:
+//
+//   Add(n)      - Add n to the set as its newest member.
+//   Remove(n)   - Remove n from the set.
+//   Touch(n)    - Make n the newest member.
+//   Oldest()    - Return the least recently used member.
+//   PopOldest() - Remove and return the least recently used member.
+//   Clear()     - Removes all elements from the set.
+type LRUSet struct {
+	members *SparseSet
+
+	// The members before and after each member in the list. Both arrays
+	// have an extra entry at index capacity for the head of the list,
+	// whose next member is the oldest and whose previous is the newest.
+	// Entries for values that are not members are meaningless.
+	prev []int
+	next []int
+}
+
+// Allocate a new, empty LRUSet able to store the integers less than
+// capacity.
+func NewLRUSet(capacity int) *LRUSet {
+	l := &LRUSet{
+		members: NewSparseSet(capacity),
+		prev:    make([]int, capacity+1, capacity+1),
+		next:    make([]int, capacity+1, capacity+1),
+	}
+
+	l.Clear()
+	return l
+}
+
+// Returns the index of the head of the list.
+func (l *LRUSet) head() int {
+	return len(l.next) - 1
+}
+
+// Links value into the list as its newest member.
+func (l *LRUSet) append(value int) {
+	head := l.head()
+	newest := l.prev[head]
+	l.prev[value], l.next[value] = newest, head
+	l.next[newest], l.prev[head] = value, value
+}
+
+// Unlinks value from the list.
+func (l *LRUSet) unlink(value int) {
+	before, after := l.prev[value], l.next[value]
+	l.next[before], l.prev[after] = after, before
+}
+
+// Returns true if value is a member of the set.
+func (l *LRUSet) Contains(value int) bool {
+	return l.members.Contains(value)
+}
+
+// Removes all elements from the set.
+func (l *LRUSet) Clear() {
+	l.members.Clear()
+	head := l.head()
+	l.prev[head], l.next[head] = head, head
+}
+
+// Returns the size of the set.
+func (l *LRUSet) Size() int {
+	return l.members.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (l *LRUSet) Capacity() int {
+	return l.members.Capacity()
+}
+
+// Adds value to the set as its newest member. Adding a value that is
+// already a member is not an error, and leaves its position unchanged;
+// use Touch to make it the newest.
+// If a value is less than zero or too large to be stored in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (l *LRUSet) Add(value int) error {
+	added, err := l.members.AddReported(value)
+	if added {
+		l.append(value)
+	}
+
+	return err
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (l *LRUSet) Remove(item int) {
+	if l.members.Contains(item) {
+		l.unlink(item)
+		l.members.Remove(item)
+	}
+}
+
+// Makes value the newest member of the set, as though it had just been
+// used. Returns true if value is a member; if it is not, nothing
+// happens.
+func (l *LRUSet) Touch(value int) bool {
+	if !l.members.Contains(value) {
+		return false
+	}
+
+	l.unlink(value)
+	l.append(value)
+	return true
+}
+
+// Returns the least recently added or touched member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (l *LRUSet) Oldest() (int, error) {
+	if l.members.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return l.next[l.head()], nil
+}
+
+// Returns the most recently added or touched member of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (l *LRUSet) Newest() (int, error) {
+	if l.members.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return l.prev[l.head()], nil
+}
+
+// Remove and return the least recently added or touched member of the
+// set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (l *LRUSet) PopOldest() (int, error) {
+	value, err := l.Oldest()
+	if err == nil {
+		l.Remove(value)
+	}
+
+	return value, err
+}
+
+// Remove and return the least recently used member, as PopOldest does.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (l *LRUSet) Pop() (int, error) {
+	return l.PopOldest()
+}
+
+// Returns a slice of ints, which are the members of the set, in no
+// particular order. Use All to visit them from oldest to newest.
+// This slice should not be modified.
+func (l *LRUSet) Values() []int {
+	return l.members.Values()
+}
+
+// Returns an iterator over the members of the set, from the least to the
+// most recently used. The set should not be modified during iteration,
+// except to remove the member being visited.
+func (l *LRUSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		head := l.head()
+		for v := l.next[head]; v != head; {
+			after := l.next[v]
+			if !yield(v) {
+				return
+			}
+
+			v = after
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLRUSet(t *testing.T) {
+	l := NewLRUSet(10)
+	_, err := l.Oldest()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	for _, v := range []int{3, 1, 4, 5, 9} {
+		err := l.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	err = l.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	l.Add(1)
+	order := slices.Collect(l.All())
+	assert(t, slices.Equal(order, []int{3, 1, 4, 5, 9}), "wrong order %v", order)
+
+	assert(t, l.Touch(3) && l.Touch(5), "3 and 5 should be members")
+	assert(t, !l.Touch(7), "7 should not be a member")
+	order = slices.Collect(l.All())
+	assert(t, slices.Equal(order, []int{1, 4, 9, 3, 5}), "wrong order after touch %v", order)
+
+	oldest, _ := l.Oldest()
+	newest, _ := l.Newest()
+	assert(t, oldest == 1 && newest == 5, "wrong ends %v, %v", oldest, newest)
+
+	l.Remove(9)
+	l.Remove(7)
+	value, err := l.PopOldest()
+	assert(t, err == nil && value == 1, "1 should be popped, got %v", value)
+	value, _ = l.Pop()
+	assert(t, value == 4, "4 should be popped, got %v", value)
+	assertMembers(t, l.Contains, l.Size(), 10, 3, 5)
+
+	for v := range l.All() {
+		l.Remove(v)
+	}
+
+	assert(t, l.Size() == 0, "removing during iteration should empty the set")
+
+	l.Add(2)
+	l.Clear()
+	_, err = l.PopOldest()
+	assert(t, err == ErrEmptySet && len(slices.Collect(l.All())) == 0, "set should be empty after clear")
+}
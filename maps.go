@@ -0,0 +1,60 @@
+package intset
+
+// Returns a new GrowSet whose members are the keys of m. Like Copy, the
+// new set is just large enough to hold the keys, from the smallest to
+// the largest.
+func FromMap(m map[int]struct{}) *GrowSet {
+	var e extent
+	for k := range m {
+		e.include(k)
+	}
+
+	result := e.newGrowSet()
+	for k := range m {
+		result.Add(k)
+	}
+
+	return result
+}
+
+// Returns a new GrowSet whose members are the keys of m that map to
+// true. Keys that map to false are not members, as they would not be of
+// a map used as a set. The new set is just large enough to hold the
+// members, from the smallest to the largest.
+func FromBoolMap(m map[int]bool) *GrowSet {
+	var e extent
+	for k, ok := range m {
+		if ok {
+			e.include(k)
+		}
+	}
+
+	result := e.newGrowSet()
+	for k, ok := range m {
+		if ok {
+			result.Add(k)
+		}
+	}
+
+	return result
+}
+
+// Returns a new map whose keys are the members of s.
+func ToMap(s IntSet) map[int]struct{} {
+	result := make(map[int]struct{}, s.Size())
+	for _, v := range s.Values() {
+		result[v] = struct{}{}
+	}
+
+	return result
+}
+
+// Returns a new map in which each member of s maps to true.
+func ToBoolMap(s IntSet) map[int]bool {
+	result := make(map[int]bool, s.Size())
+	for _, v := range s.Values() {
+		result[v] = true
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package intset
+
+import (
+	"maps"
+	"math"
+	"testing"
+)
+
+func TestMapConversions(t *testing.T) {
+	set := FromMap(map[int]struct{}{-3: {}, 0: {}, 7: {}})
+	assert(t, set.Capacity() == 11, "capacity should be 11, is %v", set.Capacity())
+	for _, v := range []int{-3, 0, 7} {
+		assert(t, set.Contains(v), "%v should be a member", v)
+	}
+
+	assert(t, set.Size() == 3, "size should be 3, is %v", set.Size())
+	assert(t, maps.Equal(ToMap(set), map[int]struct{}{-3: {}, 0: {}, 7: {}}), "ToMap should round trip")
+
+	set = FromBoolMap(map[int]bool{2: true, 5: false, 100: false})
+	assertMembers(t, set.Contains, set.Size(), 3, 2)
+	assert(t, maps.Equal(ToBoolMap(set), map[int]bool{2: true}), "ToBoolMap is %v", ToBoolMap(set))
+
+	empty := FromMap(nil)
+	assert(t, empty.Size() == 0 && len(ToMap(empty)) == 0, "an empty map should give an empty set")
+}
+
+func TestFromMapSizing(t *testing.T) {
+	set := FromMap(map[int]struct{}{1e9: {}, 1e9 + 4: {}})
+	assert(t, set.Size() == 2 && set.Contains(1e9) && set.Contains(1e9+4), "set should hold the keys")
+	assert(t, set.Capacity() == 5, "set should hold just its keys' range, holds %v", set.Capacity())
+
+	set = FromMap(map[int]struct{}{math.MaxInt: {}})
+	assert(t, set.Size() == 1 && set.Contains(math.MaxInt), "set should hold MaxInt")
+
+	set = FromBoolMap(map[int]bool{math.MaxInt: true, 0: false, math.MaxInt - 1: true})
+	assert(t, set.Size() == 2 && set.Contains(math.MaxInt) && set.Capacity() == 2, "set should hold MaxInt and MaxInt-1 only")
+}
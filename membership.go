@@ -0,0 +1,122 @@
+package intset
+
+import (
+	"math/bits"
+)
+
+// The number of sets a MultiMembership can track.
+const MaxMemberships = 64
+
+// A MultiMembership tracks up to 64 sets over the same universe of
+// integers, numbered from 0 to 63, and records for each value which of
+// them it belongs to as a bitmask, so that the question "which sets
+// contain x?" is answered in O(1) time rather than by querying each set.
+// It supports the following operations in O(1) time:
+//
+//   Add(set, n)      - Add n to the given set.
+//   Remove(set, n)   - Remove n from the given set.
+//   Contains(set, n) - Check if n is a member of the given set.
+//   Sets(n)          - Return the sets that n belongs to, as a bitmask.
+type MultiMembership struct {
+	masks []uint64
+	sizes [MaxMemberships]int
+}
+
+// Allocate a new MultiMembership over the integers less than capacity,
+// with every set empty.
+func NewMultiMembership(capacity int) *MultiMembership {
+	validateCapacity(capacity)
+	return &MultiMembership{masks: make([]uint64, capacity, capacity)}
+}
+
+// Returns the number of distinct values each set is able to store.
+func (m *MultiMembership) Capacity() int {
+	return len(m.masks)
+}
+
+// Returns an error if set does not number a set, or value is too small
+// or too large to be stored.
+func (m *MultiMembership) check(set, value int) error {
+	if set < 0 || set >= MaxMemberships {
+		return outOfRange(set, 0, MaxMemberships)
+	}
+
+	if value < 0 || value >= len(m.masks) {
+		return outOfRange(value, 0, len(m.masks))
+	}
+
+	return nil
+}
+
+// Adds value to the given set. Adding the same value multiple times is
+// not an error.
+// If set is not between 0 and 63, or value is less than zero or too
+// large to be stored, ErrValueOutOfRange is returned, otherwise nil.
+func (m *MultiMembership) Add(set, value int) error {
+	if err := m.check(set, value); err != nil {
+		return err
+	}
+
+	if m.masks[value]&(1<<set) == 0 {
+		m.masks[value] |= 1 << set
+		m.sizes[set]++
+	}
+
+	return nil
+}
+
+// Removes value from the given set. It is not an error to remove a value
+// that is not a member, or to name a set or value out of range.
+func (m *MultiMembership) Remove(set, value int) {
+	if m.Contains(set, value) {
+		m.masks[value] &^= 1 << set
+		m.sizes[set]--
+	}
+}
+
+// Removes value from every set it belongs to.
+// This takes O(k) time, where k is the number of those sets.
+func (m *MultiMembership) Forget(value int) {
+	mask := m.Sets(value)
+	if mask == 0 {
+		return
+	}
+
+	m.masks[value] = 0
+	for ; mask != 0; mask &= mask - 1 {
+		m.sizes[bits.TrailingZeros64(mask)]--
+	}
+}
+
+// Returns true if value is a member of the given set.
+func (m *MultiMembership) Contains(set, value int) bool {
+	return set >= 0 && set < MaxMemberships && m.Sets(value)&(1<<set) != 0
+}
+
+// Returns the sets that value belongs to, as a bitmask in which bit i is
+// set if value is a member of set i. Values out of range belong to no
+// set.
+func (m *MultiMembership) Sets(value int) uint64 {
+	if value < 0 || value >= len(m.masks) {
+		return 0
+	}
+
+	return m.masks[value]
+}
+
+// Returns the number of members of the given set, or 0 if set is not
+// between 0 and 63.
+func (m *MultiMembership) Size(set int) int {
+	if set < 0 || set >= MaxMemberships {
+		return 0
+	}
+
+	return m.sizes[set]
+}
+
+// Empties every set.
+// This takes O(n) time, where n == capacity.
+func (m *MultiMembership) Clear() {
+	clear(m.masks)
+	m.sizes = [MaxMemberships]int{}
+}
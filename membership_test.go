@@ -0,0 +1,39 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiMembership(t *testing.T) {
+	m := NewMultiMembership(100)
+	assert(t, m.Capacity() == 100, "capacity should be 100")
+
+	for _, pair := range [][2]int{{0, 5}, {3, 5}, {63, 5}, {3, 7}, {3, 7}} {
+		err := m.Add(pair[0], pair[1])
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, m.Sets(5) == 1|1<<3|1<<63, "wrong sets for 5: %b", m.Sets(5))
+	assert(t, m.Sets(7) == 1<<3, "wrong sets for 7: %b", m.Sets(7))
+	assert(t, m.Sets(6) == 0 && m.Sets(-1) == 0 && m.Sets(100) == 0, "6 should be in no set")
+	assert(t, m.Size(3) == 2 && m.Size(0) == 1 && m.Size(1) == 0, "wrong sizes")
+	assert(t, m.Contains(63, 5) && !m.Contains(62, 5) && !m.Contains(64, 5), "wrong membership")
+
+	m.Remove(3, 5)
+	m.Remove(3, 6)
+	m.Remove(64, 5)
+	assert(t, m.Sets(5) == 1|1<<63 && m.Size(3) == 1, "5 should have left set 3")
+
+	m.Forget(5)
+	assert(t, m.Sets(5) == 0 && m.Size(0) == 0 && m.Size(63) == 0, "5 should be in no set")
+	assert(t, m.Size(3) == 1, "set 3 should still contain 7")
+
+	err := m.Add(64, 1)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	err = m.Add(0, 100)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	m.Clear()
+	assert(t, m.Sets(7) == 0 && m.Size(3) == 0, "clear should empty every set")
+}
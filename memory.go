@@ -0,0 +1,51 @@
+package intset
+
+import (
+	"unsafe"
+)
+
+// Returns the number of bytes used by s and its arrays.
+func (s *set) memoryUsage() int {
+	return int(unsafe.Sizeof(*s)) + int(unsafe.Sizeof(int(0)))*(cap(s.sparse)+cap(s.dense))
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays. This does not depend on the number of members.
+func (g *GrowSet) MemoryUsage() int {
+	return (*set)(g).memoryUsage()
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays. This does not depend on the number of members.
+func (s *ShrinkSet) MemoryUsage() int {
+	return (*set)(s).memoryUsage()
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays. This does not depend on the number of members.
+func (s *SparseSet) MemoryUsage() int {
+	return (*set)(s).memoryUsage()
+}
+
+// Returns the number of bytes of memory currently used by the set,
+// including its backing arrays. This increases as the set grows.
+func (d *DynamicSet) MemoryUsage() int {
+	return (*set)(d).memoryUsage()
+}
+
+// Returns the number of bytes of memory used by the set, including its
+// backing arrays.
+func (a *AtomicGrowSet) MemoryUsage() int {
+	return int(unsafe.Sizeof(*a)) + 8*(cap(a.sparse)+cap(a.dense))
+}
+
+// Returns the number of bytes of memory used by the set, including the
+// storage of each of its shards.
+func (s *ShardedSet) MemoryUsage() int {
+	result := int(unsafe.Sizeof(*s)) + int(unsafe.Sizeof(shard{}))*len(s.shards)
+	for i := range s.shards {
+		result += s.shards[i].set.MemoryUsage()
+	}
+
+	return result
+}
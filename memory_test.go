@@ -0,0 +1,29 @@
+package intset
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestMemoryUsage(t *testing.T) {
+	word := int(unsafe.Sizeof(int(0)))
+
+	small, large := NewGrowSet(10), NewGrowSet(1000)
+	assert(t, large.MemoryUsage()-small.MemoryUsage() == 2*990*word, "memory usage should grow with capacity")
+	assert(t, small.MemoryUsage() >= 20*word, "memory usage should include the arrays")
+
+	before := small.MemoryUsage()
+	small.Add(3)
+	assert(t, small.MemoryUsage() == before, "memory usage should not depend on members")
+
+	assert(t, NewShrinkSet(10).MemoryUsage() == before, "ShrinkSet should use as much memory as GrowSet")
+	assert(t, NewSparseSet(10).MemoryUsage() == before, "SparseSet should use as much memory as GrowSet")
+
+	d := NewDynamicSet(10)
+	before = d.MemoryUsage()
+	d.Add(100)
+	assert(t, d.MemoryUsage() > before, "DynamicSet memory usage should grow")
+
+	assert(t, NewAtomicGrowSet(10).MemoryUsage() >= 160, "AtomicGrowSet memory usage should include the arrays")
+	assert(t, NewShardedSet(100, 4).MemoryUsage() >= 200*word, "ShardedSet memory usage should include the shards")
+}
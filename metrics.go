@@ -0,0 +1,155 @@
+package intset
+
+import (
+	"fmt"
+	"iter"
+	"sync/atomic"
+)
+
+// Metrics counts the operations performed on one or more MeteredSets,
+// so that long-lived sets in servers can be observed. Its counters are
+// updated atomically and may be read from any goroutine while the sets
+// are in use, for instance by a Prometheus CounterFunc or GaugeFunc.
+// Metrics implements expvar.Var, so it can also be published directly
+// with expvar.Publish.
+// The zero value is ready to use, and a Metrics must not be copied
+// after first use.
+type Metrics struct {
+	adds       atomic.Int64
+	removes    atomic.Int64
+	pops       atomic.Int64
+	rejections atomic.Int64
+	size       atomic.Int64
+}
+
+// Returns the number of values added to the sets that were not already
+// members.
+func (m *Metrics) Adds() int64 {
+	return m.adds.Load()
+}
+
+// Returns the number of members removed from the sets by Remove or
+// Clear.
+func (m *Metrics) Removes() int64 {
+	return m.removes.Load()
+}
+
+// Returns the number of members popped from the sets.
+func (m *Metrics) Pops() int64 {
+	return m.pops.Load()
+}
+
+// Returns the number of values that could not be added to the sets
+// because they were out of range.
+func (m *Metrics) Rejections() int64 {
+	return m.rejections.Load()
+}
+
+// Returns the total size of the sets.
+func (m *Metrics) Size() int64 {
+	return m.size.Load()
+}
+
+// Returns the counters as a JSON object, implementing expvar.Var.
+func (m *Metrics) String() string {
+	return fmt.Sprintf(`{"adds": %d, "removes": %d, "pops": %d, "rejections": %d, "size": %d}`,
+		m.Adds(), m.Removes(), m.Pops(), m.Rejections(), m.Size())
+}
+
+// A MeteredSet is a SparseSet that records the operations performed on
+// it in a Metrics. The operations take the same time as they do for a
+// SparseSet, plus an atomic update of the counters they affect. Like a
+// SparseSet, it is not safe for concurrent use, though its Metrics is.
+type MeteredSet struct {
+	members *SparseSet
+	metrics *Metrics
+}
+
+// Returns a MeteredSet recording the operations performed on set in
+// metrics, which may be shared with other sets to aggregate their
+// counts. The members of set are added to the size in metrics. set
+// should not be modified other than through the MeteredSet.
+func NewMeteredSet(set *SparseSet, metrics *Metrics) *MeteredSet {
+	metrics.size.Add(int64(set.Size()))
+	return &MeteredSet{members: set, metrics: metrics}
+}
+
+// Returns the Metrics the set records its operations in.
+func (m *MeteredSet) Metrics() *Metrics {
+	return m.metrics
+}
+
+// Returns true if value is a member of the set.
+func (m *MeteredSet) Contains(value int) bool {
+	return m.members.Contains(value)
+}
+
+// Removes all elements from the set, counting each as removed.
+func (m *MeteredSet) Clear() {
+	n := int64(m.members.Size())
+	m.members.Clear()
+	m.metrics.removes.Add(n)
+	m.metrics.size.Add(-n)
+}
+
+// Returns the size of the set.
+func (m *MeteredSet) Size() int {
+	return m.members.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (m *MeteredSet) Capacity() int {
+	return m.members.Capacity()
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is too small or too large to be stored in the set, ErrValueOutOfRange
+// is returned and the rejection is counted, otherwise nil.
+func (m *MeteredSet) Add(value int) error {
+	added, err := m.members.AddReported(value)
+	switch {
+	case err != nil:
+		m.metrics.rejections.Add(1)
+	case added:
+		m.metrics.adds.Add(1)
+		m.metrics.size.Add(1)
+	}
+
+	return err
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (m *MeteredSet) Remove(item int) {
+	if m.members.Contains(item) {
+		m.members.Remove(item)
+		m.metrics.removes.Add(1)
+		m.metrics.size.Add(-1)
+	}
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering, as SparseSet.Pop does.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (m *MeteredSet) Pop() (int, error) {
+	value, err := m.members.Pop()
+	if err == nil {
+		m.metrics.pops.Add(1)
+		m.metrics.size.Add(-1)
+	}
+
+	return value, err
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (m *MeteredSet) Values() []int {
+	return m.members.Values()
+}
+
+// Returns an iterator over the members of the set. The member being
+// visited may safely be removed during iteration.
+// Iteration does not allocate.
+func (m *MeteredSet) All() iter.Seq[int] {
+	return m.members.All()
+}
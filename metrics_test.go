@@ -0,0 +1,49 @@
+package intset
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+)
+
+var _ expvar.Var = (*Metrics)(nil)
+
+func TestMeteredSet(t *testing.T) {
+	var metrics Metrics
+	base := NewSparseSet(10)
+	base.Add(1)
+
+	m := NewMeteredSet(base, &metrics)
+	assert(t, m.Metrics() == &metrics, "Metrics should return the metrics")
+	assert(t, metrics.Size() == 1, "size should start at 1, is %v", metrics.Size())
+
+	m.Add(2)
+	m.Add(3)
+	m.Add(3)
+	err := m.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	m.Remove(2)
+	m.Remove(2)
+	m.Pop()
+	assertMembers(t, m.Contains, m.Size(), 10, 1)
+
+	assert(t, metrics.Adds() == 2, "adds should be 2, is %v", metrics.Adds())
+	assert(t, metrics.Rejections() == 1, "rejections should be 1, is %v", metrics.Rejections())
+	assert(t, metrics.Removes() == 1, "removes should be 1, is %v", metrics.Removes())
+	assert(t, metrics.Pops() == 1, "pops should be 1, is %v", metrics.Pops())
+	assert(t, metrics.Size() == 1, "size should be 1, is %v", metrics.Size())
+
+	other := NewMeteredSet(NewSparseSet(5), &metrics)
+	other.Add(4)
+	assert(t, metrics.Size() == 2, "shared metrics should total the sizes, is %v", metrics.Size())
+
+	m.Clear()
+	_, err = m.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, metrics.Removes() == 2 && metrics.Size() == 1, "Clear should count its removals")
+
+	var decoded map[string]int64
+	assert(t, json.Unmarshal([]byte(metrics.String()), &decoded) == nil, "String should be JSON: %v", metrics.String())
+	assert(t, decoded["adds"] == 3 && decoded["size"] == 1, "decoded metrics are %v", decoded)
+}
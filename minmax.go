@@ -0,0 +1,135 @@
+package intset
+
+// A GrowSet records its smallest and largest members as they are added.
+// Popping either of them marks the record as stale, and the next call to
+// Min or Max recomputes it from the members of the set.
+//
+// A ShrinkSet instead treats its record as a lower and upper bound:
+// removing a member never invalidates the bounds, and Min and Max
+// tighten them on demand by scanning towards the middle of the set.
+// Since a bound only moves outwards again when a value is added or the
+// set is refilled, the scanning cost is amortized across removals.
+
+// Updates the recorded extremes to account for value being added to
+// the set.
+func (g *GrowSet) extend(value int) {
+	if g.n == 0 {
+		g.min, g.max, g.stale = value, value, false
+		return
+	}
+
+	if value < g.min {
+		g.min = value
+	}
+
+	if value > g.max {
+		g.max = value
+	}
+}
+
+// Recomputes the recorded extremes from the members of the set.
+func (g *GrowSet) refresh() {
+	if !g.stale {
+		return
+	}
+
+	g.min, g.max = g.dense[0], g.dense[0]
+	for _, v := range g.dense[1:g.n] {
+		if v < g.min {
+			g.min = v
+		}
+
+		if v > g.max {
+			g.max = v
+		}
+	}
+
+	g.stale = false
+}
+
+// Returns the smallest member of the set.
+// This takes O(1) time unless the previous smallest or largest member
+// has since been popped, in which case it takes O(n) time once.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *GrowSet) Min() (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	g.refresh()
+	return g.min, nil
+}
+
+// Returns the largest member of the set.
+// This takes O(1) time unless the previous smallest or largest member
+// has since been popped, in which case it takes O(n) time once.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *GrowSet) Max() (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	g.refresh()
+	return g.max, nil
+}
+
+// Updates the recorded bounds to account for value being added to
+// the set.
+func (s *ShrinkSet) extend(value int) {
+	if s.n == 0 {
+		s.min, s.max = value, value
+		return
+	}
+
+	if value < s.min {
+		s.min = value
+	}
+
+	if value > s.max {
+		s.max = value
+	}
+}
+
+// Returns the smallest member of the set, in amortized O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *ShrinkSet) Min() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	for !s.Contains(s.min) {
+		s.min++
+	}
+
+	return s.min, nil
+}
+
+// Returns the largest member of the set, in amortized O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *ShrinkSet) Max() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	for !s.Contains(s.max) {
+		s.max--
+	}
+
+	return s.max, nil
+}
+
+// Remove and return the smallest member of the set.
+// A run of calls takes amortized O(1) time each, as Min does, but adding
+// back a value smaller than the current smallest member means the next
+// call may scan over the values removed since, taking up to O(n) time,
+// where n == capacity.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *ShrinkSet) PopMin() (int, error) {
+	value, err := s.Min()
+	if err != nil {
+		return 0, err
+	}
+
+	s.Remove(value)
+	return value, nil
+}
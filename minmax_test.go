@@ -0,0 +1,89 @@
+package intset
+
+import (
+	"testing"
+)
+
+func assertMinMax(t *testing.T, min, max func() (int, error), expectedMin, expectedMax int) {
+	v, err := min()
+	assert(t, err == nil && v == expectedMin, "min should be %v, is %v (%v)", expectedMin, v, err)
+
+	v, err = max()
+	assert(t, err == nil && v == expectedMax, "max should be %v, is %v (%v)", expectedMax, v, err)
+}
+
+func TestGrowSetMinMax(t *testing.T) {
+	set := NewGrowSet(10)
+
+	_, err := set.Min()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(4)
+	assertMinMax(t, set.Min, set.Max, 4, 4)
+
+	set.Add(7)
+	set.Add(2)
+	set.Add(5)
+	assertMinMax(t, set.Min, set.Max, 2, 7)
+
+	set.Pop()
+	assertMinMax(t, set.Min, set.Max, 2, 7)
+
+	set.Pop()
+	assertMinMax(t, set.Min, set.Max, 4, 7)
+
+	set.IntersectWith(growSetOf(10, 4))
+	assertMinMax(t, set.Min, set.Max, 4, 4)
+
+	set.Clear()
+	_, err = set.Max()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(9)
+	assertMinMax(t, set.Min, set.Max, 9, 9)
+}
+
+func TestShrinkSetMinMax(t *testing.T) {
+	set := NewShrinkSet(6)
+	assertMinMax(t, set.Min, set.Max, 0, 5)
+
+	set.Remove(0)
+	set.Remove(1)
+	set.Remove(5)
+	assertMinMax(t, set.Min, set.Max, 2, 4)
+
+	set.Add(1)
+	assertMinMax(t, set.Min, set.Max, 1, 4)
+
+	for set.Size() > 0 {
+		set.Pop()
+	}
+
+	_, err := set.Min()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(3)
+	assertMinMax(t, set.Min, set.Max, 3, 3)
+
+	set.Refill()
+	assertMinMax(t, set.Min, set.Max, 0, 5)
+}
+
+func TestShrinkSetPopMin(t *testing.T) {
+	set := NewShrinkSet(5)
+	set.Remove(1)
+
+	for _, want := range []int{0, 2} {
+		value, err := set.PopMin()
+		assert(t, err == nil && value == want, "expected %v, got %v", want, value)
+	}
+
+	set.Add(1)
+	for _, want := range []int{1, 3, 4} {
+		value, err := set.PopMin()
+		assert(t, err == nil && value == want, "expected %v, got %v", want, value)
+	}
+
+	_, err := set.PopMin()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
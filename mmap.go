@@ -0,0 +1,143 @@
+//go:build unix
+
+package intset
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Identifies a file holding a MappedSet.
+const mappedMagic = 0x74657369
+
+// The header of a mapped file holds, as ints, mappedMagic, the size of
+// an int in bytes, the capacity of the set, and its size.
+const mappedHeaderWords = 4
+
+// A MappedSet is a SparseSet whose arrays are stored in a memory-mapped
+// file rather than on the Go heap. This allows sets with hundreds of
+// millions of slots to be paged in and out by the operating system, and
+// to persist across process restarts.
+//
+// The size of the set is written to the file by Sync and Close; if the
+// process exits without calling either, the file may not reflect recent
+// changes. Operations that replace the storage of the set, such as Grow,
+// Reset, CopyFrom, decoding, and modifying a set that has been forked,
+// detach it from the file, after which changes are no longer saved and
+// Sync returns an error.
+//
+// The file stores ints in the native byte order and size, so it can only
+// be opened on the same kind of platform that created it.
+type MappedSet struct {
+	*SparseSet
+	file *os.File
+	data []byte
+
+	// The header and dense array in data, used to save the size of the
+	// set and to check that it is still using the file.
+	header []int
+	mapped []int
+}
+
+// Opens the file at path as a MappedSet able to store the integers less
+// than capacity, creating it if it does not exist. An existing file
+// keeps its members, and must have been created with the same capacity.
+// If the file cannot be opened or mapped, the result will be nil and
+// error will describe why; if it exists but does not hold a valid set
+// of that capacity, error will be ErrInvalidEncoding.
+// Opening an existing file takes O(k) time, where k is the size of the
+// set, to check its contents.
+func OpenMappedSet(path string, capacity int) (*MappedSet, error) {
+	validateCapacity(capacity)
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := mapSet(file, capacity)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Maps file as a MappedSet of the given capacity, initializing it if it
+// is empty.
+func mapSet(file *os.File, capacity int) (*MappedSet, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	wordSize := int(unsafe.Sizeof(int(0)))
+	size := wordSize * (mappedHeaderWords + 2*capacity)
+	fresh := info.Size() == 0
+	if fresh {
+		if err := file.Truncate(int64(size)); err != nil {
+			return nil, err
+		}
+	} else if info.Size() != int64(size) {
+		return nil, ErrInvalidEncoding
+	}
+
+	data, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, fmt.Errorf("intset: mapping %s: %w", file.Name(), err)
+	}
+
+	words := unsafe.Slice((*int)(unsafe.Pointer(unsafe.SliceData(data))), size/wordSize)
+	header := words[:mappedHeaderWords]
+	if fresh {
+		header[0], header[1], header[2], header[3] = mappedMagic, wordSize, capacity, 0
+	}
+
+	s := &SparseSet{
+		n:      header[3],
+		sparse: words[mappedHeaderWords : mappedHeaderWords+capacity : mappedHeaderWords+capacity],
+		dense:  words[mappedHeaderWords+capacity:],
+	}
+
+	if header[0] != mappedMagic || header[1] != wordSize || header[2] != capacity || s.CheckInvariants() != nil {
+		syscall.Munmap(data)
+		return nil, ErrInvalidEncoding
+	}
+
+	return &MappedSet{SparseSet: s, file: file, data: data, header: header, mapped: s.dense}, nil
+}
+
+// Returned by Sync and Close when the set has been detached from its
+// file, so its changes can no longer be saved.
+var errDetached = errors.New("intset: mapped set detached from its file")
+
+// Writes the size of the set to the file, and flushes the file to
+// stable storage. If the set has been detached from the file, the file
+// is left as it was and an error is returned.
+func (m *MappedSet) Sync() error {
+	if len(m.dense) != len(m.mapped) || len(m.dense) > 0 && &m.dense[0] != &m.mapped[0] {
+		return errDetached
+	}
+
+	m.header[3] = m.n
+	return m.file.Sync()
+}
+
+// Saves the set as Sync does, then unmaps and closes the file. The set
+// must not be used afterwards.
+func (m *MappedSet) Close() error {
+	err := m.Sync()
+	if unmapErr := syscall.Munmap(m.data); err == nil {
+		err = unmapErr
+	}
+
+	if closeErr := m.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	m.SparseSet, m.data, m.header, m.mapped = nil, nil, nil, nil
+	return err
+}
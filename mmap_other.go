@@ -0,0 +1,30 @@
+//go:build !unix
+
+package intset
+
+import (
+	"errors"
+)
+
+// A MappedSet is a SparseSet whose arrays are stored in a memory-mapped
+// file. Memory mapping is not supported on this platform.
+type MappedSet struct {
+	*SparseSet
+}
+
+// Memory mapping is not supported on this platform, so the result will
+// always be nil and error will be errors.ErrUnsupported.
+func OpenMappedSet(path string, capacity int) (*MappedSet, error) {
+	validateCapacity(capacity)
+	return nil, errors.ErrUnsupported
+}
+
+// Does nothing, as a MappedSet cannot be opened on this platform.
+func (m *MappedSet) Sync() error {
+	return errors.ErrUnsupported
+}
+
+// Does nothing, as a MappedSet cannot be opened on this platform.
+func (m *MappedSet) Close() error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,69 @@
+//go:build unix
+
+package intset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMappedSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set")
+	set, err := OpenMappedSet(path, 1000)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, set.Size() == 0 && set.Capacity() == 1000, "new set should be empty")
+
+	set.AddAll(1, 500, 999)
+	set.Remove(500)
+	assert(t, set.Close() == nil, "close should succeed")
+
+	set, err = OpenMappedSet(path, 1000)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 1000, 1, 999)
+
+	set.Add(7)
+	assert(t, set.Sync() == nil, "sync should succeed")
+	assert(t, set.Close() == nil, "close should succeed")
+
+	set, err = OpenMappedSet(path, 1000)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 1000, 1, 7, 999)
+	set.Close()
+
+	set, err = OpenMappedSet(path, 1000)
+	assert(t, err == nil, "error is not nil: %v", err)
+	set.Grow(2000)
+	set.Add(1500)
+	assert(t, set.Sync() != nil, "sync should fail once detached")
+	set.Close()
+
+	set, err = OpenMappedSet(path, 1000)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, set.Contains, set.Size(), 1000, 1, 7, 999)
+	set.Close()
+
+	_, err = OpenMappedSet(path, 999)
+	assert(t, err == ErrInvalidEncoding, "wrong capacity should fail, got %v", err)
+}
+
+func TestMappedSetCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "set")
+	set, err := OpenMappedSet(path, 10)
+	assert(t, err == nil, "error is not nil: %v", err)
+	set.AddAll(2, 3)
+	set.Close()
+
+	data, err := os.ReadFile(path)
+	assert(t, err == nil, "error is not nil: %v", err)
+	data[len(data)-1] ^= 0xff
+	data[len(data)-80] ^= 0x0f
+	os.WriteFile(path, data, 0o666)
+
+	_, err = OpenMappedSet(path, 10)
+	assert(t, err == ErrInvalidEncoding, "corrupt file should fail, got %v", err)
+
+	os.WriteFile(path, []byte("not a set"), 0o666)
+	_, err = OpenMappedSet(path, 10)
+	assert(t, err == ErrInvalidEncoding, "short file should fail, got %v", err)
+}
@@ -0,0 +1,40 @@
+package intset
+
+// Removes value from the set and adds it to dst, in O(1) time, as a
+// single step: value is never a member of both sets or of neither, so
+// this suits transferring values between sets that track the states of
+// items, such as pending and active. The result is true if value was
+// moved, and false if it is not a member of the set, in which case
+// neither set is changed. If value is already a member of dst, it is
+// only removed from the set.
+// If value is a member of the set but too small or too large to be
+// stored in dst, neither set is changed, and the result will be false
+// and error will be ErrValueOutOfRange.
+func (s *SparseSet) Move(value int, dst *SparseSet) (bool, error) {
+	if !s.Contains(value) {
+		return false, nil
+	}
+
+	if !(*set)(dst).inRange(value) {
+		return false, (*set)(dst).outOfRange(value)
+	}
+
+	(*set)(s).own()
+	index := s.sparse[value-s.offset]
+	last := s.dense[s.n-1]
+	s.dense[index] = last
+	s.sparse[last-s.offset] = index
+	s.n--
+	s.mods++
+
+	// value is known to be in range for dst, so its membership can be
+	// checked without checking the range again.
+	if index := dst.sparse[value-dst.offset]; index < 0 || index >= dst.n || dst.dense[index] != value {
+		(*set)(dst).own()
+		dst.dense[dst.n] = value
+		dst.sparse[value-dst.offset] = dst.n
+		dst.n++
+	}
+
+	return true, nil
+}
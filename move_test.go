@@ -0,0 +1,40 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSparseSetMove(t *testing.T) {
+	pending := NewSparseSet(10)
+	pending.AddAll(1, 2, 3)
+	active := NewSparseSet(10)
+
+	moved, err := pending.Move(2, active)
+	assert(t, moved && err == nil, "2 should have been moved: %v", err)
+	assertMembers(t, pending.Contains, pending.Size(), 10, 1, 3)
+	assertMembers(t, active.Contains, active.Size(), 10, 2)
+
+	moved, err = pending.Move(2, active)
+	assert(t, !moved && err == nil, "2 is no longer pending and should not be moved")
+
+	active.Add(3)
+	moved, _ = pending.Move(3, active)
+	assert(t, moved, "3 should have been moved")
+	assertMembers(t, pending.Contains, pending.Size(), 10, 1)
+	assertMembers(t, active.Contains, active.Size(), 10, 2, 3)
+
+	small := NewSparseSetRange(2, 5)
+	moved, err = pending.Move(1, small)
+	assert(t, !moved && errors.Is(err, ErrValueOutOfRange), "1 does not fit in small and should not be moved")
+	assertMembers(t, pending.Contains, pending.Size(), 10, 1)
+
+	moved, err = active.Move(3, small)
+	assert(t, moved && err == nil, "3 should have been moved into the range set")
+	assertMembers(t, active.Contains, active.Size(), 10, 2)
+	assert(t, small.Contains(3) && small.Size() == 1, "small should contain 3")
+
+	fork := active.Fork()
+	active.Move(2, pending)
+	assert(t, fork.Contains(2) && fork.Size() == 1, "moving should not change a fork")
+}
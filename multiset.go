@@ -0,0 +1,127 @@
+package intset
+
+import (
+	"iter"
+)
+
+// A MultiSet is a set in which each member has a count of how many
+// times it has been added. It supports the following operations with
+// the associated time complexity:
+//
+//   Add(n)     - Increment the count of n, in O(1) time.
+//   Remove(n)  - Decrement the count of n, in O(1) time.
+//   Discard(n) - Remove every occurrence of n, in O(1) time.
+//   Count(n)   - Return the count of n, in O(1) time.
+//   Clear()    - Removes all elements from the set, in O(1) time.
+//
+// A value is a member of the set while its count is greater than zero.
+// Size and Values report the distinct members of the set; Total
+// reports the sum of their counts.
+type MultiSet struct {
+	counts *SparseMap[int]
+	total  int
+}
+
+// Allocate a new MultiSet.
+// The resulting set will be able to store the integers less than
+// capacity.
+func NewMultiSet(capacity int) *MultiSet {
+	return &MultiSet{counts: NewSparseMap[int](capacity)}
+}
+
+// Returns true if value is a member of the set.
+func (m *MultiSet) Contains(value int) bool {
+	return m.counts.Contains(value)
+}
+
+// Returns the number of times value has been added to the set, less
+// the number of times it has been removed.
+func (m *MultiSet) Count(value int) int {
+	count, _ := m.counts.Get(value)
+	return count
+}
+
+// Removes all elements from the set.
+func (m *MultiSet) Clear() {
+	m.counts.Clear()
+	m.total = 0
+}
+
+// Returns the number of distinct members of the set.
+func (m *MultiSet) Size() int {
+	return m.counts.Size()
+}
+
+// Returns the sum of the counts of every member of the set.
+func (m *MultiSet) Total() int {
+	return m.total
+}
+
+// Returns the number of distinct values the set is able to store.
+func (m *MultiSet) Capacity() int {
+	return m.counts.Capacity()
+}
+
+// Increments the count of value, adding it to the set if it is not
+// already a member.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (m *MultiSet) Add(value int) error {
+	if err := m.counts.Set(value, m.Count(value)+1); err != nil {
+		return err
+	}
+
+	m.total++
+	return nil
+}
+
+// Decrements the count of item, removing it from the set when the count
+// reaches zero. It is not an error to remove an item that does not
+// exist.
+func (m *MultiSet) Remove(item int) {
+	count := m.Count(item)
+	switch {
+	case count == 0:
+		return
+	case count == 1:
+		m.counts.Delete(item)
+	default:
+		m.counts.Set(item, count-1)
+	}
+
+	m.total--
+}
+
+// Removes every occurrence of item from the set. It is not an error to
+// remove an item that does not exist.
+func (m *MultiSet) Discard(item int) {
+	m.total -= m.Count(item)
+	m.counts.Delete(item)
+}
+
+// Removes one occurrence of an arbitrary member of the set and returns
+// that member.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (m *MultiSet) Pop() (int, error) {
+	keys := m.counts.Keys()
+	if len(keys) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	value := keys[len(keys)-1]
+	m.Remove(value)
+	return value, nil
+}
+
+// Returns a slice containing the distinct members of the set.
+// This slice should not be modified.
+func (m *MultiSet) Values() []int {
+	return m.counts.Keys()
+}
+
+// Returns an iterator over the members of the set and their counts.
+// The member being visited may safely be removed during iteration.
+// Iteration does not allocate.
+func (m *MultiSet) All() iter.Seq2[int, int] {
+	return m.counts.All()
+}
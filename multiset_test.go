@@ -0,0 +1,63 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultiSet(t *testing.T) {
+	set := NewMultiSet(10)
+
+	for _, v := range []int{3, 3, 3, 5, 7} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, set.Size() == 3, "set size should be 3")
+	assert(t, set.Total() == 5, "set total should be 5")
+	assert(t, set.Count(3) == 3, "count of 3 should be 3")
+	assert(t, set.Count(4) == 0, "count of 4 should be 0")
+
+	set.Remove(3)
+	set.Remove(5)
+	set.Remove(5)
+	assert(t, set.Count(3) == 2, "count of 3 should be 2")
+	assert(t, !set.Contains(5), "set should not contain 5")
+	assert(t, set.Total() == 3, "set total should be 3")
+
+	set.Discard(3)
+	assert(t, !set.Contains(3) && set.Total() == 1, "set should not contain 3")
+
+	err := set.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assert(t, set.Total() == 1, "a rejected value should not be counted")
+
+	set.Add(7)
+	for i := 0; i < 2; i++ {
+		v, err := set.Pop()
+		assert(t, err == nil && v == 7, "pop should return 7")
+	}
+
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	set.Add(1)
+	set.Clear()
+	assert(t, set.Size() == 0 && set.Total() == 0 && set.Count(1) == 0, "set should be empty")
+}
+
+func TestMultiSetAll(t *testing.T) {
+	set := NewMultiSet(10)
+	for v := 0; v < 10; v++ {
+		for i := 0; i <= v; i++ {
+			set.Add(v)
+		}
+	}
+
+	for v, count := range set.All() {
+		assert(t, count == v+1, "count of %v should be %v", v, v+1)
+		set.Discard(v)
+	}
+
+	assert(t, set.Size() == 0 && set.Total() == 0, "set should be empty")
+}
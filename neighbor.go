@@ -0,0 +1,305 @@
+package intset
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Returns the smallest member greater than value of a set of size
+// members, all between lowest and limit. Values are tested in turn with
+// contains while there are fewer of them than members, and otherwise
+// every member is visited with all, so this takes O(min(d, k)) time,
+// where d is the distance to the result and k is size.
+func successor(value, lowest, limit, size int, contains func(int) bool, all iter.Seq[int]) (int, bool) {
+	if size == 0 || value >= limit-1 {
+		return 0, false
+	}
+
+	start := max(value+1, lowest)
+	end := limit
+	if size < limit-start {
+		end = start + size
+	}
+
+	for v := start; v < end; v++ {
+		if contains(v) {
+			return v, true
+		}
+	}
+
+	if end == limit {
+		return 0, false
+	}
+
+	result, found := 0, false
+	for v := range all {
+		if v > value && (!found || v < result) {
+			result, found = v, true
+		}
+	}
+
+	return result, found
+}
+
+// Returns the largest member less than value of a set of size members,
+// all between lowest and limit, in the same way as successor.
+func predecessor(value, lowest, limit, size int, contains func(int) bool, all iter.Seq[int]) (int, bool) {
+	if size == 0 || value <= lowest {
+		return 0, false
+	}
+
+	start := min(value-1, limit-1)
+	end := lowest - 1
+	if size < start-end {
+		end = start - size
+	}
+
+	for v := start; v > end; v-- {
+		if contains(v) {
+			return v, true
+		}
+	}
+
+	if end == lowest-1 {
+		return 0, false
+	}
+
+	result, found := 0, false
+	for v := range all {
+		if v < value && (!found || v > result) {
+			result, found = v, true
+		}
+	}
+
+	return result, found
+}
+
+// Returns the position of the first bit set in words at or after i, or
+// -1 if there is none.
+func bitmapNext(words []uint64, i int) int {
+	i = max(i, 0)
+	w := i / 64
+	if w >= len(words) {
+		return -1
+	}
+
+	word := words[w] &^ (1<<(i%64) - 1)
+	for word == 0 {
+		w++
+		if w == len(words) {
+			return -1
+		}
+
+		word = words[w]
+	}
+
+	return 64*w + bits.TrailingZeros64(word)
+}
+
+// Returns the position of the last bit set in words at or before i, or
+// -1 if there is none.
+func bitmapPrev(words []uint64, i int) int {
+	if i < 0 || len(words) == 0 {
+		return -1
+	}
+
+	i = min(i, 64*len(words)-1)
+	w := i / 64
+	word := words[w] & (^uint64(0) >> (63 - i%64))
+	for word == 0 {
+		w--
+		if w < 0 {
+			return -1
+		}
+
+		word = words[w]
+	}
+
+	return 64*w + 63 - bits.LeadingZeros64(word)
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (g *GrowSet) NextAfter(value int) (int, bool) {
+	lowest, limit := (*set)(g).bounds()
+	return successor(value, lowest, limit, g.n, g.Contains, g.All())
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (g *GrowSet) PrevBefore(value int) (int, bool) {
+	lowest, limit := (*set)(g).bounds()
+	return predecessor(value, lowest, limit, g.n, g.Contains, g.All())
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (s *ShrinkSet) NextAfter(value int) (int, bool) {
+	lowest, limit := (*set)(s).bounds()
+	return successor(value, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (s *ShrinkSet) PrevBefore(value int) (int, bool) {
+	lowest, limit := (*set)(s).bounds()
+	return predecessor(value, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (s *SparseSet) NextAfter(value int) (int, bool) {
+	lowest, limit := (*set)(s).bounds()
+	return successor(value, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(min(d, k)) time, where d is the distance from value to
+// the result and k is the size of the set.
+func (s *SparseSet) PrevBefore(value int) (int, bool) {
+	lowest, limit := (*set)(s).bounds()
+	return predecessor(value, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes time proportional to the height of the set's summary
+// hierarchy.
+func (b *BitSet) NextAfter(value int) (int, bool) {
+	if value >= b.capacity-1 {
+		return 0, false
+	}
+
+	if i := b.next(0, value+1); i >= 0 {
+		return i, true
+	}
+
+	return 0, false
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes time proportional to the height of the set's summary
+// hierarchy.
+func (b *BitSet) PrevBefore(value int) (int, bool) {
+	if value <= 0 {
+		return 0, false
+	}
+
+	if i := b.prev(0, value-1); i >= 0 {
+		return i, true
+	}
+
+	return 0, false
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(log k) time, where k is the size of the set, when the
+// members are stored as a slice, and O(d/64) time, where d is the
+// distance from value to the result, when they are stored as a bitmap.
+func (f *FrozenSet) NextAfter(value int) (int, bool) {
+	if f.n == 0 || value == math.MaxInt {
+		return 0, false
+	}
+
+	if f.bitmap != nil {
+		if i := bitmapNext(f.bitmap, max(value+1-f.offset, 0)); i >= 0 {
+			return f.offset + i, true
+		}
+
+		return 0, false
+	}
+
+	i, _ := slices.BinarySearch(f.sorted, value+1)
+	if i == len(f.sorted) {
+		return 0, false
+	}
+
+	return f.sorted[i], true
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(log k) time, where k is the size of the set, when the
+// members are stored as a slice, and O(d/64) time, where d is the
+// distance from value to the result, when they are stored as a bitmap.
+func (f *FrozenSet) PrevBefore(value int) (int, bool) {
+	if f.n == 0 {
+		return 0, false
+	}
+
+	if f.bitmap != nil {
+		if value <= f.offset {
+			return 0, false
+		}
+
+		if i := bitmapPrev(f.bitmap, value-1-f.offset); i >= 0 {
+			return f.offset + i, true
+		}
+
+		return 0, false
+	}
+
+	i, _ := slices.BinarySearch(f.sorted, value)
+	if i == 0 {
+		return 0, false
+	}
+
+	return f.sorted[i-1], true
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(log r) time, where r is the number of runs.
+func (s *IntervalSet) NextAfter(value int) (int, bool) {
+	if value == math.MaxInt {
+		return 0, false
+	}
+
+	i := s.search(value + 1)
+	if i == len(s.runs) {
+		return 0, false
+	}
+
+	return max(s.runs[i].start, value+1), true
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(log r) time, where r is the number of runs.
+func (s *IntervalSet) PrevBefore(value int) (int, bool) {
+	if value == math.MinInt {
+		return 0, false
+	}
+
+	i := s.search(value - 1)
+	if i < len(s.runs) && s.runs[i].start <= value-1 {
+		return value - 1, true
+	}
+
+	if i == 0 {
+		return 0, false
+	}
+
+	return s.runs[i-1].last, true
+}
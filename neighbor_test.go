@@ -0,0 +1,97 @@
+package intset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Returns the expected results of NextAfter and PrevBefore for value,
+// given the sorted members of a set.
+func expectedNeighbors(values []int, value int) (int, bool, int, bool) {
+	i, found := slices.BinarySearch(values, value)
+	next, hasNext, prev, hasPrev := 0, false, 0, false
+	j := i
+	if found {
+		j++
+	}
+
+	if j < len(values) {
+		next, hasNext = values[j], true
+	}
+
+	if i > 0 {
+		prev, hasPrev = values[i-1], true
+	}
+
+	return next, hasNext, prev, hasPrev
+}
+
+func TestNeighbors(t *testing.T) {
+	type neighbors interface {
+		NextAfter(int) (int, bool)
+		PrevBefore(int) (int, bool)
+	}
+
+	for trial := 0; trial < 30; trial++ {
+		size := rand.Intn(100)
+		if trial%3 == 0 {
+			size = rand.Intn(4)
+		}
+
+		grow := NewGrowSetRange(-50, 150)
+		sparse := NewSparseSetRange(-50, 150)
+		shrink := NewShrinkSetRange(-50, 150)
+		shrink.RemoveRange(-50, 150)
+		bitset := NewBitSet(150)
+		intervals := NewIntervalSet()
+
+		for i := 0; i < size; i++ {
+			v := rand.Intn(150)
+			grow.Add(v)
+			sparse.Add(v)
+			shrink.Add(v)
+			bitset.Add(v)
+			intervals.Add(v)
+		}
+
+		values := bitset.Values()
+		sets := map[string]neighbors{
+			"GrowSet":     grow,
+			"SparseSet":   sparse,
+			"ShrinkSet":   shrink,
+			"BitSet":      bitset,
+			"FrozenSet":   grow.Freeze(),
+			"IntervalSet": intervals,
+		}
+
+		for value := -60; value < 160; value++ {
+			next, hasNext, prev, hasPrev := expectedNeighbors(values, value)
+			for name, s := range sets {
+				got, ok := s.NextAfter(value)
+				assert(t, ok == hasNext && got == next, "%v: next after %v should be %v, %v, got %v, %v", name, value, next, hasNext, got, ok)
+				got, ok = s.PrevBefore(value)
+				assert(t, ok == hasPrev && got == prev, "%v: previous before %v should be %v, %v, got %v, %v", name, value, prev, hasPrev, got, ok)
+			}
+		}
+	}
+}
+
+func TestFrozenSetNeighborsNegative(t *testing.T) {
+	// Far apart members are stored as a slice, and close ones as a bitmap.
+	for _, members := range [][]int{{-10, 99999}, {-10, -3, 5}} {
+		grow := NewGrowSetRange(-10, 100000)
+		for _, v := range members {
+			grow.Add(v)
+		}
+
+		frozen := grow.Freeze()
+		for _, value := range []int{-11, -10, -9, 0, 5, 6, 99999, 100000} {
+			next, hasNext, prev, hasPrev := expectedNeighbors(members, value)
+			got, ok := frozen.NextAfter(value)
+			assert(t, ok == hasNext && got == next, "%v: next after %v should be %v, %v, got %v, %v", members, value, next, hasNext, got, ok)
+			got, ok = frozen.PrevBefore(value)
+			assert(t, ok == hasPrev && got == prev, "%v: previous before %v should be %v, %v, got %v, %v", members, value, prev, hasPrev, got, ok)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package intset
+
+import (
+	"iter"
+)
+
+// An ObservedSet is a SparseSet that calls registered functions with
+// each value added to or removed from it, so that dependent structures
+// such as indexes, caches, or user interface state can stay in step
+// with the set without polling Values. The operations take the same
+// time as they do for a SparseSet, plus the time taken by the hooks.
+//
+// Hooks are called after the set has changed, in the order they were
+// registered, and only for values whose membership actually changed.
+// A hook must not modify the set.
+//
+// OnMove hooks are instead called when a member changes slot in the
+// dense array, so that per-member data kept in parallel arrays indexed
+// by slot (see SparseSet.Index) can be moved to match.
+type ObservedSet struct {
+	members  *SparseSet
+	onAdd    []func(int)
+	onRemove []func(int)
+	onMove   []func(int, int, int)
+}
+
+// Allocate a new, empty ObservedSet able to store the integers less
+// than capacity.
+func NewObservedSet(capacity int) *ObservedSet {
+	return &ObservedSet{members: NewSparseSet(capacity)}
+}
+
+// Registers hook to be called with each value added to the set.
+func (o *ObservedSet) OnAdd(hook func(value int)) {
+	o.onAdd = append(o.onAdd, hook)
+}
+
+// Registers hook to be called with each value removed from the set,
+// whether by Remove, Pop, or Clear.
+func (o *ObservedSet) OnRemove(hook func(value int)) {
+	o.onRemove = append(o.onRemove, hook)
+}
+
+// Registers hook to be called when removing a member moves value from
+// slot from of the dense array into slot to, which the removed member
+// vacated. A member is added in slot Size() - 1, and Pop and Clear
+// remove members from the last slot, so Remove is the only operation
+// that moves one.
+func (o *ObservedSet) OnMove(hook func(value, from, to int)) {
+	o.onMove = append(o.onMove, hook)
+}
+
+// Calls each of hooks with value.
+func notify(hooks []func(int), value int) {
+	for _, hook := range hooks {
+		hook(value)
+	}
+}
+
+// Returns true if value is a member of the set.
+func (o *ObservedSet) Contains(value int) bool {
+	return o.members.Contains(value)
+}
+
+// Removes all elements from the set, calling the OnRemove hooks with
+// each of them. With no OnRemove hooks this takes O(1) time, and
+// otherwise O(k) time, where k is the size of the set.
+func (o *ObservedSet) Clear() {
+	if len(o.onRemove) == 0 {
+		o.members.Clear()
+		return
+	}
+
+	for o.members.Size() > 0 {
+		value, _ := o.members.Pop()
+		notify(o.onRemove, value)
+	}
+}
+
+// Returns the size of the set.
+func (o *ObservedSet) Size() int {
+	return o.members.Size()
+}
+
+// Returns the number of distinct values the set is able to store.
+func (o *ObservedSet) Capacity() int {
+	return o.members.Capacity()
+}
+
+// Adds value to the set, calling the OnAdd hooks if it was not already
+// a member. Adding the same value multiple times is not an error.
+// If a value is too small or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (o *ObservedSet) Add(value int) error {
+	added, err := o.members.AddReported(value)
+	if added {
+		notify(o.onAdd, value)
+	}
+
+	return err
+}
+
+// Remove the item from the set, calling the OnRemove hooks if it was a
+// member, and then the OnMove hooks if the member in the last slot was
+// moved into its place. It is not an error to remove an item that does not exist.
+func (o *ObservedSet) Remove(item int) {
+	to, ok := o.members.Index(item)
+	if !ok {
+		return
+	}
+
+	from := o.members.Size() - 1
+	last, _ := o.members.At(from)
+	o.members.Remove(item)
+	notify(o.onRemove, item)
+
+	if from != to {
+		for _, hook := range o.onMove {
+			hook(last, from, to)
+		}
+	}
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering, as SparseSet.Pop does, calling the OnRemove hooks with it.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (o *ObservedSet) Pop() (int, error) {
+	value, err := o.members.Pop()
+	if err == nil {
+		notify(o.onRemove, value)
+	}
+
+	return value, err
+}
+
+// Returns the slot of the dense array holding value, and true, in O(1)
+// time. If value is not a member of the set, the result is 0 and false.
+func (o *ObservedSet) Index(value int) (int, bool) {
+	return o.members.Index(value)
+}
+
+// Returns the member of the set in slot i of the dense array, in O(1)
+// time.
+// If i is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (o *ObservedSet) At(i int) (int, error) {
+	return o.members.At(i)
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (o *ObservedSet) Values() []int {
+	return o.members.Values()
+}
+
+// Returns an iterator over the members of the set. The member being
+// visited may safely be removed during iteration.
+// Iteration does not allocate.
+func (o *ObservedSet) All() iter.Seq[int] {
+	return o.members.All()
+}
@@ -0,0 +1,75 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestObservedSet(t *testing.T) {
+	o := NewObservedSet(10)
+	var added, removed, order []int
+	o.OnAdd(func(v int) { added = append(added, v) })
+	o.OnAdd(func(v int) { order = append(order, v) })
+	o.OnRemove(func(v int) {
+		assert(t, !o.Contains(v), "%v should be removed before the hook is called", v)
+		removed = append(removed, v)
+	})
+
+	o.Add(1)
+	o.Add(2)
+	o.Add(2)
+	err := o.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assert(t, slices.Equal(added, []int{1, 2}) && slices.Equal(order, added), "added are %v", added)
+
+	o.Remove(1)
+	o.Remove(1)
+	o.Add(3)
+	o.Add(4)
+	v, _ := o.Pop()
+	assert(t, v == 4, "pop should return 4, returned %v", v)
+	assert(t, slices.Equal(removed, []int{1, 4}), "removed are %v", removed)
+
+	o.Clear()
+	assertMembers(t, o.Contains, o.Size(), o.Capacity())
+	slices.Sort(removed)
+	assert(t, slices.Equal(removed, []int{1, 2, 3, 4}), "Clear should report each member, removed are %v", removed)
+
+	_, err = o.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestObservedSetOnMove(t *testing.T) {
+	// Keep a name for each member in an array parallel to the dense one.
+	o := NewObservedSet(10)
+	names := make([]string, 10)
+	o.OnAdd(func(v int) {
+		i, _ := o.Index(v)
+		names[i] = string(rune('a' + v))
+	})
+
+	moves := 0
+	o.OnMove(func(v, from, to int) {
+		assert(t, o.Contains(v), "%v should still be a member", v)
+		index, _ := o.Index(v)
+		assert(t, index == to, "%v should be in slot %v, not %v", v, to, index)
+		names[to] = names[from]
+		moves++
+	})
+
+	for _, v := range []int{3, 1, 4, 5, 9, 2, 6} {
+		o.Add(v)
+	}
+
+	o.Remove(1)
+	o.Remove(6)
+	o.Remove(7)
+	o.Pop()
+	o.Remove(3)
+	assert(t, moves == 3, "removing 1, 6, and 3 should each move a member, moved %v", moves)
+
+	for i, v := range o.Values() {
+		assert(t, names[i] == string(rune('a'+v)), "slot %v should be named for %v, not %q", i, v, names[i])
+	}
+}
@@ -0,0 +1,157 @@
+package intset
+
+import (
+	"iter"
+	"slices"
+)
+
+// An OrderedSet is a fixed-capacity set whose Pop returns members in the
+// order they were added, so that it can serve as a deduplicating FIFO
+// queue, such as the frontier of a breadth-first search. Its dense array
+// is used as a ring buffer: members are added at the tail and popped
+// from the head. It supports the following operations in O(1) time:
+//
+//   Add(n)   - Add n at the back of the queue, unless it is a member.
+//   Peek()   - Return the member that was added first.
+//   Pop()    - Remove and return the member that was added first.
+//   Clear()  - Remove all members.
+//
+// A popped value may be added again, and joins the back of the queue.
+type OrderedSet struct {
+	head   int
+	n      int
+	sparse []int
+	dense  []int
+}
+
+// Allocate a new, empty OrderedSet able to store the integers less than
+// capacity.
+func NewOrderedSet(capacity int) *OrderedSet {
+	validateCapacity(capacity)
+	return &OrderedSet{
+		sparse: make([]int, capacity, capacity),
+		dense:  make([]int, capacity, capacity),
+	}
+}
+
+// Returns true if value is a member of the set.
+func (o *OrderedSet) Contains(value int) bool {
+	if value < 0 || value >= len(o.sparse) {
+		return false
+	}
+
+	index := o.sparse[value]
+	position := index - o.head
+	if position < 0 {
+		position += len(o.dense)
+	}
+
+	return position < o.n && o.dense[index] == value
+}
+
+// Returns the size of the set.
+func (o *OrderedSet) Size() int {
+	return o.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (o *OrderedSet) Capacity() int {
+	return len(o.sparse)
+}
+
+// Removes all elements from the set.
+func (o *OrderedSet) Clear() {
+	o.head, o.n = 0, 0
+}
+
+// Adds value to the back of the queue. Adding a value that is already a
+// member is not an error, and leaves its position unchanged.
+// If a value is less than zero or too large to be stored in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (o *OrderedSet) Add(value int) error {
+	if value < 0 || value >= len(o.sparse) {
+		return outOfRange(value, 0, len(o.sparse))
+	}
+
+	if !o.Contains(value) {
+		index := o.head + o.n
+		if index >= len(o.dense) {
+			index -= len(o.dense)
+		}
+
+		o.dense[index] = value
+		o.sparse[value] = index
+		o.n++
+	}
+
+	return nil
+}
+
+// Returns the member of the set that was added first, without removing
+// it.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (o *OrderedSet) Peek() (int, error) {
+	if o.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return o.dense[o.head], nil
+}
+
+// Remove and return the member of the set that was added first.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (o *OrderedSet) Pop() (int, error) {
+	if o.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	value := o.dense[o.head]
+	o.head++
+	if o.head == len(o.dense) {
+		o.head = 0
+	}
+
+	o.n--
+	return value, nil
+}
+
+// Returns a slice containing the members of the set, in the order they
+// will be popped. This slice should not be modified.
+// If the members wrap around the end of the ring buffer, they are first
+// rotated to its start, which takes O(capacity) time; otherwise this
+// takes O(1) time.
+func (o *OrderedSet) Values() []int {
+	if o.head+o.n > len(o.dense) {
+		// Rotate the buffer left by head, by reversing each part and
+		// then the whole.
+		slices.Reverse(o.dense[:o.head])
+		slices.Reverse(o.dense[o.head:])
+		slices.Reverse(o.dense)
+		for i, v := range o.dense[:o.n] {
+			o.sparse[v] = i
+		}
+
+		o.head = 0
+	}
+
+	return o.dense[o.head : o.head+o.n]
+}
+
+// Returns an iterator over the members of the set, most recently added
+// first, without removing them. The set must not be modified during
+// iteration.
+// Iteration does not allocate.
+func (o *OrderedSet) Backward() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := o.n - 1; i >= 0; i-- {
+			index := o.head + i
+			if index >= len(o.dense) {
+				index -= len(o.dense)
+			}
+
+			if !yield(o.dense[index]) {
+				return
+			}
+		}
+	}
+}
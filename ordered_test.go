@@ -0,0 +1,69 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestOrderedSetFIFO(t *testing.T) {
+	set := NewOrderedSet(10)
+	for _, v := range []int{7, 2, 9, 2, 0, 7} {
+		assert(t, set.Add(v) == nil, "adding %v should succeed", v)
+	}
+
+	assert(t, set.Size() == 4, "set size should be 4")
+	assert(t, slices.Equal(set.Values(), []int{7, 2, 9, 0}), "values should be in insertion order, not %v", set.Values())
+
+	peeked, err := set.Peek()
+	assert(t, err == nil && peeked == 7, "peek should return 7, not %v", peeked)
+
+	for _, want := range []int{7, 2} {
+		popped, err := set.Pop()
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, popped == want, "popped %v, expected %v", popped, want)
+		assert(t, !set.Contains(popped), "set should not contain popped value")
+	}
+
+	// A popped value joins the back of the queue when it is added again.
+	set.Add(7)
+	for _, want := range []int{9, 0, 7} {
+		popped, _ := set.Pop()
+		assert(t, popped == want, "popped %v, expected %v", popped, want)
+	}
+
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, err = set.Peek()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, errors.Is(set.Add(10), ErrValueOutOfRange), "adding 10 should fail")
+}
+
+func TestOrderedSetWrap(t *testing.T) {
+	set := NewOrderedSet(5)
+	var queue []int
+	for i := 0; i < 100; i++ {
+		v := (i * 3) % 5
+		if set.Size() < 3 {
+			set.Add(v)
+			if !slices.Contains(queue, v) {
+				queue = append(queue, v)
+			}
+		} else {
+			popped, _ := set.Pop()
+			assert(t, popped == queue[0], "popped %v, expected %v", popped, queue[0])
+			queue = queue[1:]
+		}
+
+		for v := 0; v < 5; v++ {
+			assert(t, set.Contains(v) == slices.Contains(queue, v), "membership of %v is wrong", v)
+		}
+
+		if i%7 == 0 {
+			assert(t, slices.Equal(set.Values(), queue), "values should be %v, not %v", queue, set.Values())
+		}
+	}
+
+	set.Clear()
+	assertMembers(t, set.Contains, set.Size(), set.Capacity())
+}
@@ -0,0 +1,168 @@
+package intset
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// The number of values covered by each page of a PagedSet's sparse
+// array.
+const pageSize = 4096
+
+// A PagedSet is a SparseSet whose sparse array is split into pages of
+// 4096 entries, each allocated the first time a value within it is
+// added, and whose dense array grows with the number of members. A set
+// over a universe of a billion values holding only thousands of members
+// therefore needs only a table of page pointers and the pages its
+// members fall in, rather than two billion-entry arrays. It supports the
+// following operations with the associated time complexity:
+//
+//   Add(n)    - Add integer n to the set, in amortized O(1) time.
+//   Remove(n) - Remove n from the set, in O(1) time.
+//   Clear()   - Removes all elements from the set, in O(1) time.
+//
+// Like a DynamicSet, a PagedSet allocates after construction, when a
+// value is added to a page that has not been used before or the dense
+// array must grow. Pages are kept once allocated, including by Clear,
+// so a set that is refilled with values in the same pages does not
+// allocate again.
+type PagedSet struct {
+	capacity int
+	pages    []*[pageSize]int
+	dense    []int
+}
+
+// Allocate a new, empty PagedSet.
+// The resulting set will be able to store the integers less than
+// capacity. Only the table of pages, one pointer for every 4096 values,
+// is allocated up front.
+func NewPagedSet(capacity int) *PagedSet {
+	validateCapacity(capacity)
+	return &PagedSet{
+		capacity: capacity,
+		pages:    make([]*[pageSize]int, (capacity+pageSize-1)/pageSize),
+	}
+}
+
+// Returns true if value is a member of the set.
+func (p *PagedSet) Contains(value int) bool {
+	if value < 0 || value >= p.capacity {
+		return false
+	}
+
+	page := p.pages[value/pageSize]
+	if page == nil {
+		return false
+	}
+
+	index := page[value%pageSize]
+	return index >= 0 && index < len(p.dense) && p.dense[index] == value
+}
+
+// Removes all elements from the set.
+func (p *PagedSet) Clear() {
+	p.dense = p.dense[:0]
+}
+
+// Returns the size of the set.
+func (p *PagedSet) Size() int {
+	return len(p.dense)
+}
+
+// Returns the number of distinct values the set is able to store.
+func (p *PagedSet) Capacity() int {
+	return p.capacity
+}
+
+// Returns the number of pages of the sparse array that have been
+// allocated.
+func (p *PagedSet) Pages() int {
+	count := 0
+	for _, page := range p.pages {
+		if page != nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (p *PagedSet) Add(value int) error {
+	if value < 0 || value >= p.capacity {
+		return outOfRange(value, 0, p.capacity)
+	}
+
+	if p.Contains(value) {
+		return nil
+	}
+
+	page := p.pages[value/pageSize]
+	if page == nil {
+		page = new([pageSize]int)
+		p.pages[value/pageSize] = page
+	}
+
+	page[value%pageSize] = len(p.dense)
+	p.dense = append(p.dense, value)
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (p *PagedSet) Remove(item int) {
+	if !p.Contains(item) {
+		return
+	}
+
+	index := p.pages[item/pageSize][item%pageSize]
+	last := p.dense[len(p.dense)-1]
+	p.dense[index] = last
+	p.pages[last/pageSize][last%pageSize] = index
+	p.dense = p.dense[:len(p.dense)-1]
+}
+
+// Remove and return the member at the end of the set's internal
+// ordering. With no intervening removals, this is the most recently
+// added member.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (p *PagedSet) Pop() (int, error) {
+	if len(p.dense) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	value := p.dense[len(p.dense)-1]
+	p.dense = p.dense[:len(p.dense)-1]
+	return value, nil
+}
+
+// Returns a slice of ints, which are the members of the set.
+// This slice should not be modified.
+func (p *PagedSet) Values() []int {
+	return p.dense
+}
+
+// Returns an iterator over the members of the set.
+// Members are visited from the end of the dense array backwards, so
+// the member being visited may safely be removed during iteration, and
+// members added during iteration are not visited.
+// Iteration does not allocate.
+func (p *PagedSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := len(p.dense) - 1; i >= 0; i-- {
+			if i < len(p.dense) && !yield(p.dense[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the number of bytes of memory currently used by the set,
+// including its page table, allocated pages, and dense array. This
+// increases as the set grows.
+func (p *PagedSet) MemoryUsage() int {
+	return int(unsafe.Sizeof(*p)) + 8*cap(p.pages) +
+		p.Pages()*int(unsafe.Sizeof([pageSize]int{})) + int(unsafe.Sizeof(int(0)))*cap(p.dense)
+}
@@ -0,0 +1,66 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestPagedSet(t *testing.T) {
+	set := NewPagedSet(1_000_000_000)
+	assert(t, set.Pages() == 0, "no pages should be allocated, %v are", set.Pages())
+
+	for _, v := range []int{0, 4095, 4096, 999_999_999, 4096} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	assert(t, set.Pages() == 3, "three pages should be allocated, %v are", set.Pages())
+	assertMembers(t, set.Contains, set.Size()-1, 10000, 0, 4095, 4096)
+	assert(t, set.Contains(999_999_999), "999999999 should be a member")
+	assert(t, !set.Contains(8192) && !set.Contains(-1), "unallocated pages should contain nothing")
+
+	err := set.Add(1_000_000_000)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	set.Remove(0)
+	set.Remove(8192)
+	assertMembers(t, set.Contains, set.Size()-1, 10000, 4095, 4096)
+
+	v, err := set.Pop()
+	assert(t, err == nil && v == 4096, "pop should return 4096, returned %v", v)
+
+	set.Clear()
+	assertMembers(t, set.Contains, set.Size(), 10000)
+	assert(t, !set.Contains(999_999_999), "999999999 should not be a member")
+	assert(t, set.Pages() == 3, "Clear should keep allocated pages")
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	assert(t, set.MemoryUsage() < 4_000_000, "memory usage should be small, is %v", set.MemoryUsage())
+}
+
+func TestPagedSetRandom(t *testing.T) {
+	set := NewPagedSet(100_000)
+	reference := NewSparseSet(100_000)
+	for i := 0; i < 10000; i++ {
+		v := rand.Intn(100_000)
+		if rand.Intn(3) == 0 {
+			set.Remove(v)
+			reference.Remove(v)
+		} else {
+			set.Add(v)
+			reference.Add(v)
+		}
+	}
+
+	assertMembers(t, set.Contains, set.Size(), set.Capacity(), reference.Values()...)
+
+	count := 0
+	for v := range set.All() {
+		set.Remove(v)
+		count++
+	}
+
+	assert(t, count == reference.Size() && set.Size() == 0, "All should visit every member")
+}
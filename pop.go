@@ -0,0 +1,80 @@
+package intset
+
+// Removes up to len(buf) members from the set, writing them into buf,
+// and returns how many were written. Members are removed in the same
+// order as repeated calls to Pop would remove them.
+// This takes O(k) time, where k is the number of members removed.
+func (g *GrowSet) PopInto(buf []int) int {
+	k := min(len(buf), g.n)
+	for i := 0; i < k; i++ {
+		value := g.dense[g.n-1-i]
+		buf[i] = value
+		g.stale = g.stale || value == g.min || value == g.max
+	}
+
+	g.n -= k
+	g.mods += uint(k)
+	return k
+}
+
+// Removes up to len(buf) members from the set, writing them into buf,
+// and returns how many were written. Members are taken from the end of
+// the set's internal ordering, which needs no rearranging of the set,
+// so the order differs from that of repeated calls to Pop.
+// This takes O(k) time, where k is the number of members removed.
+func (s *ShrinkSet) PopInto(buf []int) int {
+	k := min(len(buf), s.n)
+	for i := 0; i < k; i++ {
+		buf[i] = s.at(s.n - 1 - i)
+	}
+
+	s.n -= k
+	s.mods += uint(k)
+	return k
+}
+
+// Removes up to len(buf) members from the set, writing them into buf,
+// and returns how many were written. Members are removed in the same
+// order as repeated calls to Pop would remove them.
+// This takes O(k) time, where k is the number of members removed.
+func (s *SparseSet) PopInto(buf []int) int {
+	k := min(len(buf), s.n)
+	for i := 0; i < k; i++ {
+		buf[i] = s.dense[s.n-1-i]
+	}
+
+	s.n -= k
+	s.mods += uint(k)
+	return k
+}
+
+// Returns the member that Pop would remove, without removing it.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *GrowSet) Peek() (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return g.dense[g.n-1], nil
+}
+
+// Returns the member that Pop would remove, without removing it.
+// If the set is empty, the result will be zero and
+// error will be ErrEmptySet.
+func (s *ShrinkSet) Peek() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.at(0), nil
+}
+
+// Returns the member that Pop would remove, without removing it.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SparseSet) Peek() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.dense[s.n-1], nil
+}
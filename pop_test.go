@@ -0,0 +1,83 @@
+package intset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrowSetPopInto(t *testing.T) {
+	set := growSetOf(10, 3, 1, 4, 8, 5)
+	buf := make([]int, 2)
+
+	n := set.PopInto(buf)
+	assert(t, n == 2, "should have popped 2, popped %v", n)
+	assert(t, slices.Equal(buf, []int{5, 8}), "popped %v", buf)
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	max, _ := set.Max()
+	assert(t, max == 4, "max should be 4, is %v", max)
+
+	buf = make([]int, 5)
+	n = set.PopInto(buf)
+	assert(t, n == 3, "should have popped 3, popped %v", n)
+	assert(t, slices.Equal(buf[:n], []int{4, 1, 3}), "popped %v", buf[:n])
+	assert(t, set.PopInto(buf) == 0, "empty set should pop nothing")
+}
+
+func TestShrinkSetPopInto(t *testing.T) {
+	set := NewShrinkSet(6)
+	set.Remove(2)
+	buf := make([]int, 3)
+
+	seen := make(map[int]bool)
+	for set.Size() > 0 {
+		n := set.PopInto(buf)
+		for _, v := range buf[:n] {
+			assert(t, !set.Contains(v), "set should not contain popped value %v", v)
+			assert(t, !seen[v], "duplicate popped value %v", v)
+			seen[v] = true
+		}
+	}
+
+	assert(t, len(seen) == 5 && !seen[2], "popped the wrong values: %v", seen)
+}
+
+func TestSparseSetPopInto(t *testing.T) {
+	set := NewSparseSet(10)
+	for _, v := range []int{3, 1, 4} {
+		set.Add(v)
+	}
+
+	buf := make([]int, 4)
+	n := set.PopInto(buf)
+	assert(t, n == 3, "should have popped 3, popped %v", n)
+	assert(t, slices.Equal(buf[:n], []int{4, 1, 3}), "popped %v", buf[:n])
+	assert(t, set.Size() == 0, "set size should be 0")
+}
+
+func TestPeek(t *testing.T) {
+	g := growSetOf(10, 2, 5, 7)
+	s := NewShrinkSet(6)
+	s.Remove(0)
+	p := NewSparseSet(10)
+	p.Add(4)
+	p.Add(9)
+
+	for _, set := range []interface {
+		IntSet
+		Peek() (int, error)
+	}{g, s, p} {
+		for set.Size() > 0 {
+			size := set.Size()
+			peeked, err := set.Peek()
+			assert(t, err == nil, "error is not nil: %v", err)
+			assert(t, set.Size() == size, "Peek should not change the size of the set")
+
+			popped, _ := set.Pop()
+			assert(t, peeked == popped, "peeked %v but popped %v", peeked, popped)
+		}
+
+		_, err := set.Peek()
+		assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	}
+}
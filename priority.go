@@ -0,0 +1,250 @@
+package intset
+
+import (
+	"cmp"
+)
+
+// A PrioritySet is a set in which each member has a priority, kept in
+// two heaps so that the members with the highest and lowest priorities
+// can be found quickly. Each heap records the position of every member
+// in an array indexed by value, as a SparseSet does, so membership is
+// still checked in O(1) time. It supports the following operations with
+// the associated time complexity:
+//
+//   Add(n, p)    - Add n with priority p, in O(log n) time.
+//   Remove(n)    - Remove n from the set, in O(log n) time.
+//   Priority(n)  - Return the priority of n, in O(1) time.
+//   Highest()    - Return the member with the highest priority, in O(1) time.
+//   PopHighest() - Remove and return that member, in O(log n) time.
+//   Lowest()     - Return the member with the lowest priority, in O(1) time.
+//   PopLowest()  - Remove and return that member, in O(log n) time.
+//   Clear()      - Remove all elements from the set, in O(1) time.
+//
+// Members with equal priorities are returned in an arbitrary order.
+type PrioritySet[P cmp.Ordered] struct {
+	n        int
+	priority []P
+	low      priorityHeap
+	high     priorityHeap
+}
+
+// A binary heap of the members of a PrioritySet, with the position of
+// each member in it.
+type priorityHeap struct {
+	values   []int
+	position []int
+
+	// When highest is true, the member with the highest priority is at
+	// the root; otherwise the one with the lowest is.
+	highest bool
+}
+
+// Allocate a new, empty PrioritySet able to store the integers less
+// than capacity.
+func NewPrioritySet[P cmp.Ordered](capacity int) *PrioritySet[P] {
+	validateCapacity(capacity)
+	return &PrioritySet[P]{
+		priority: make([]P, capacity, capacity),
+		low:      newPriorityHeap(capacity, false),
+		high:     newPriorityHeap(capacity, true),
+	}
+}
+
+// Allocates the arrays of a heap able to hold capacity members.
+func newPriorityHeap(capacity int, highest bool) priorityHeap {
+	return priorityHeap{
+		values:   make([]int, capacity, capacity),
+		position: make([]int, capacity, capacity),
+		highest:  highest,
+	}
+}
+
+// Returns true if the member at position i of h should be nearer the
+// root than the one at position j.
+func before[P cmp.Ordered](h *priorityHeap, priority []P, i, j int) bool {
+	a, b := priority[h.values[i]], priority[h.values[j]]
+	if h.highest {
+		return a > b
+	}
+
+	return a < b
+}
+
+// Exchanges the members at positions i and j of h.
+func (h *priorityHeap) swap(i, j int) {
+	h.values[i], h.values[j] = h.values[j], h.values[i]
+	h.position[h.values[i]] = i
+	h.position[h.values[j]] = j
+}
+
+// Moves the member at position i of h, whose priority may have changed,
+// to its proper place in the first n positions.
+func fix[P cmp.Ordered](h *priorityHeap, priority []P, i, n int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !before(h, priority, i, parent) {
+			break
+		}
+
+		h.swap(i, parent)
+		i = parent
+	}
+
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+
+		if child+1 < n && before(h, priority, child+1, child) {
+			child++
+		}
+
+		if !before(h, priority, child, i) {
+			return
+		}
+
+		h.swap(i, child)
+		i = child
+	}
+}
+
+// Returns true if value is a member of the set.
+func (s *PrioritySet[P]) Contains(value int) bool {
+	if value < 0 || value >= len(s.priority) {
+		return false
+	}
+
+	index := s.low.position[value]
+	return index >= 0 && index < s.n && s.low.values[index] == value
+}
+
+// Removes all elements from the set.
+func (s *PrioritySet[P]) Clear() {
+	s.n = 0
+}
+
+// Returns the size of the set.
+func (s *PrioritySet[P]) Size() int {
+	return s.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *PrioritySet[P]) Capacity() int {
+	return len(s.priority)
+}
+
+// Returns the priority of value, and true if it is a member of the set.
+// If it is not, the result will be the zero value of P and false.
+func (s *PrioritySet[P]) Priority(value int) (P, bool) {
+	if !s.Contains(value) {
+		var zero P
+		return zero, false
+	}
+
+	return s.priority[value], true
+}
+
+// Adds value to the set with the given priority. If value is already a
+// member, its priority is changed instead.
+// This takes O(log n) time, where n is the size of the set.
+// If a value is less than zero or too large to be stored in the set,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (s *PrioritySet[P]) Add(value int, priority P) error {
+	if value < 0 || value >= len(s.priority) {
+		return outOfRange(value, 0, len(s.priority))
+	}
+
+	s.priority[value] = priority
+	if s.Contains(value) {
+		fix(&s.low, s.priority, s.low.position[value], s.n)
+		fix(&s.high, s.priority, s.high.position[value], s.n)
+		return nil
+	}
+
+	for _, h := range [...]*priorityHeap{&s.low, &s.high} {
+		h.values[s.n] = value
+		h.position[value] = s.n
+	}
+
+	s.n++
+	fix(&s.low, s.priority, s.n-1, s.n)
+	fix(&s.high, s.priority, s.n-1, s.n)
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+// This takes O(log n) time, where n is the size of the set.
+func (s *PrioritySet[P]) Remove(item int) {
+	if !s.Contains(item) {
+		return
+	}
+
+	s.n--
+	for _, h := range [...]*priorityHeap{&s.low, &s.high} {
+		i := h.position[item]
+		h.swap(i, s.n)
+		if i < s.n {
+			fix(h, s.priority, i, s.n)
+		}
+	}
+}
+
+// Returns the member of the set with the highest priority.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *PrioritySet[P]) Highest() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.high.values[0], nil
+}
+
+// Returns the member of the set with the lowest priority.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *PrioritySet[P]) Lowest() (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.low.values[0], nil
+}
+
+// Remove and return the member of the set with the highest priority.
+// This takes O(log n) time, where n is the size of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *PrioritySet[P]) PopHighest() (int, error) {
+	value, err := s.Highest()
+	if err == nil {
+		s.Remove(value)
+	}
+
+	return value, err
+}
+
+// Remove and return the member of the set with the lowest priority.
+// This takes O(log n) time, where n is the size of the set.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *PrioritySet[P]) PopLowest() (int, error) {
+	value, err := s.Lowest()
+	if err == nil {
+		s.Remove(value)
+	}
+
+	return value, err
+}
+
+// Remove and return the member with the highest priority, as PopHighest
+// does.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *PrioritySet[P]) Pop() (int, error) {
+	return s.PopHighest()
+}
+
+// Returns a slice of ints, which are the members of the set, in no
+// particular order.
+// This slice should not be modified.
+func (s *PrioritySet[P]) Values() []int {
+	return s.low.values[:s.n]
+}
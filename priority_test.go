@@ -0,0 +1,91 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestPrioritySet(t *testing.T) {
+	s := NewPrioritySet[float64](10)
+	_, err := s.PopHighest()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+
+	for v, p := range map[int]float64{1: 0.5, 2: 3, 5: -1, 7: 2} {
+		err := s.Add(v, p)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	err = s.Add(10, 1)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	assertMembers(t, s.Contains, s.Size(), 10, 1, 2, 5, 7)
+
+	highest, _ := s.Highest()
+	lowest, _ := s.Lowest()
+	assert(t, highest == 2 && lowest == 5, "wrong extremes %v, %v", highest, lowest)
+
+	s.Add(1, 4)
+	p, ok := s.Priority(1)
+	assert(t, ok && p == 4, "priority of 1 should be 4, got %v", p)
+	_, ok = s.Priority(3)
+	assert(t, !ok, "3 should have no priority")
+
+	value, _ := s.PopHighest()
+	assert(t, value == 1, "1 should be popped first, got %v", value)
+	value, _ = s.PopLowest()
+	assert(t, value == 5, "5 should be popped, got %v", value)
+
+	s.Remove(7)
+	s.Remove(7)
+	assertMembers(t, s.Contains, s.Size(), 10, 2)
+
+	s.Clear()
+	assert(t, s.Size() == 0 && !s.Contains(2), "set should be empty")
+}
+
+func TestPrioritySetRandom(t *testing.T) {
+	const capacity = 200
+	s := NewPrioritySet[int](capacity)
+	want := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		v := rand.Intn(capacity)
+		switch rand.Intn(4) {
+		case 0, 1:
+			p := rand.Intn(50)
+			s.Add(v, p)
+			want[v] = p
+		case 2:
+			s.Remove(v)
+			delete(want, v)
+		case 3:
+			pop, expectHigh := s.PopLowest, false
+			if rand.Intn(2) == 0 {
+				pop, expectHigh = s.PopHighest, true
+			}
+
+			value, err := pop()
+			if len(want) == 0 {
+				assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+				continue
+			}
+
+			for _, p := range want {
+				if expectHigh {
+					assert(t, p <= want[value], "popped %v with priority %v below %v", value, want[value], p)
+				} else {
+					assert(t, p >= want[value], "popped %v with priority %v above %v", value, want[value], p)
+				}
+			}
+
+			delete(want, value)
+		}
+
+		assert(t, s.Size() == len(want), "size should be %v, is %v", len(want), s.Size())
+	}
+
+	for v := 0; v < capacity; v++ {
+		_, member := want[v]
+		assert(t, s.Contains(v) == member, "membership of %v should be %v", v, member)
+	}
+}
@@ -0,0 +1,180 @@
+package intset
+
+import (
+	"math/rand"
+)
+
+// Swaps the members at indices i and j of the dense array.
+func (s *set) swap(i, j int) {
+	s.own()
+	a, b := s.dense[i], s.dense[j]
+	s.dense[i], s.dense[j] = b, a
+	s.sparse[a-s.offset], s.sparse[b-s.offset] = j, i
+	s.mods++
+}
+
+// Swaps the members at indices i and j of the dense array.
+func (s *ShrinkSet) swap(i, j int) {
+	a, b := s.at(i), s.at(j)
+	s.put(i, b)
+	s.put(j, a)
+	s.mods++
+}
+
+// Returns k distinct members of a set of the given size chosen
+// uniformly at random using r, where at returns the member in a slot of
+// the dense array. This runs a partial Fisher-Yates shuffle over the
+// first k slots without writing to the set: moved records the members
+// that the shuffle would have swapped into later slots. Sampling is
+// therefore a read, so it neither disturbs iteration nor copies or
+// races on storage shared with a fork.
+func sample(size int, k int, r *rand.Rand, at func(int) int) []int {
+	if k > size {
+		k = size
+	}
+
+	if k < 0 {
+		k = 0
+	}
+
+	result := make([]int, k)
+	moved := make(map[int]int, k)
+	member := func(i int) int {
+		if v, ok := moved[i]; ok {
+			return v
+		}
+
+		return at(i)
+	}
+
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(size-i)
+		result[i], moved[j] = member(j), member(i)
+	}
+
+	return result
+}
+
+// Remove and return a member of the set chosen uniformly at random
+// using r, in O(1) time. Supplying a seeded r makes the result
+// reproducible.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *GrowSet) PopRandom(r *rand.Rand) (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	(*set)(g).swap(r.Intn(g.n), g.n-1)
+	return g.Pop()
+}
+
+// Remove and return a member of the set chosen uniformly at random
+// using r, in O(1) time. Supplying a seeded r makes the result
+// reproducible.
+// If the set is empty, the result will be zero and
+// error will be ErrEmptySet.
+func (s *ShrinkSet) PopRandom(r *rand.Rand) (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	removed := s.at(r.Intn(s.n))
+	s.Remove(removed)
+	return removed, nil
+}
+
+// Remove and return a member of the set chosen uniformly at random
+// using r, in O(1) time. Supplying a seeded r makes the result
+// reproducible.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SparseSet) PopRandom(r *rand.Rand) (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	removed := s.dense[r.Intn(s.n)]
+	s.Remove(removed)
+	return removed, nil
+}
+
+// Returns a member of the set chosen uniformly at random using r,
+// without removing it, in O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (g *GrowSet) Random(r *rand.Rand) (int, error) {
+	if g.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return g.dense[r.Intn(g.n)], nil
+}
+
+// Returns a member of the set chosen uniformly at random using r,
+// without removing it, in O(1) time.
+// If the set is empty, the result will be zero and
+// error will be ErrEmptySet.
+func (s *ShrinkSet) Random(r *rand.Rand) (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.at(r.Intn(s.n)), nil
+}
+
+// Returns a member of the set chosen uniformly at random using r,
+// without removing it, in O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *SparseSet) Random(r *rand.Rand) (int, error) {
+	if s.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return s.dense[r.Intn(s.n)], nil
+}
+
+// Returns a newly allocated slice of k distinct members of the set,
+// chosen uniformly at random using r, without removing them.
+// If k is larger than the size of the set, every member is returned.
+// This takes O(k) time.
+func (g *GrowSet) Sample(k int, r *rand.Rand) []int {
+	return sample(g.n, k, r, func(i int) int { return g.dense[i] })
+}
+
+// Returns a newly allocated slice of k distinct members of the set,
+// chosen uniformly at random using r, without removing them.
+// If k is larger than the size of the set, every member is returned.
+// This takes O(k) time.
+func (s *ShrinkSet) Sample(k int, r *rand.Rand) []int {
+	return sample(s.n, k, r, s.at)
+}
+
+// Returns a newly allocated slice of k distinct members of the set,
+// chosen uniformly at random using r, without removing them.
+// If k is larger than the size of the set, every member is returned.
+// This takes O(k) time.
+func (s *SparseSet) Sample(k int, r *rand.Rand) []int {
+	return sample(s.n, k, r, func(i int) int { return s.dense[i] })
+}
+
+// Randomly permutes the members of the set using r, so that Values, All,
+// and Pop visit them in a random order, in O(n) time, where n is the
+// size of the set. Randomizing the order once up front is cheaper than
+// calling PopRandom repeatedly.
+func (g *GrowSet) Shuffle(r *rand.Rand) {
+	r.Shuffle(g.n, (*set)(g).swap)
+}
+
+// Randomly permutes the members of the set using r, so that Values, All,
+// and Pop visit them in a random order, in O(n) time, where n is the
+// size of the set. Randomizing the order once up front is cheaper than
+// calling PopRandom repeatedly.
+func (s *ShrinkSet) Shuffle(r *rand.Rand) {
+	r.Shuffle(s.n, s.swap)
+}
+
+// Randomly permutes the members of the set using r, so that Values, All,
+// and Pop visit them in a random order, in O(n) time, where n is the
+// size of the set. Randomizing the order once up front is cheaper than
+// calling PopRandom repeatedly.
+func (s *SparseSet) Shuffle(r *rand.Rand) {
+	r.Shuffle(s.n, (*set)(s).swap)
+}
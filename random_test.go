@@ -0,0 +1,251 @@
+package intset
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func drainRandom(t *testing.T, size int, pop func() (int, error)) []int {
+	var order []int
+	seen := make(map[int]bool)
+	for i := 0; i < size; i++ {
+		v, err := pop()
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, !seen[v], "duplicate popped value %v", v)
+		seen[v] = true
+		order = append(order, v)
+	}
+
+	_, err := pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	return order
+}
+
+func TestGrowSetPopRandom(t *testing.T) {
+	pop := func(seed int64) []int {
+		set := growSetOf(10, 0, 2, 4, 6, 8)
+		r := rand.New(rand.NewSource(seed))
+		return drainRandom(t, 5, func() (int, error) { return set.PopRandom(r) })
+	}
+
+	assert(t, slices.Equal(pop(1), pop(1)), "same seed should give the same order")
+
+	set := growSetOf(10, 0, 2, 4, 6, 8)
+	set.PopRandom(rand.New(rand.NewSource(1)))
+	for _, v := range set.Values() {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+}
+
+func TestShrinkSetPopRandom(t *testing.T) {
+	pop := func(seed int64) []int {
+		set := NewShrinkSet(8)
+		set.Remove(3)
+		r := rand.New(rand.NewSource(seed))
+		return drainRandom(t, 7, func() (int, error) { return set.PopRandom(r) })
+	}
+
+	assert(t, slices.Equal(pop(2), pop(2)), "same seed should give the same order")
+}
+
+func TestSparseSetPopRandom(t *testing.T) {
+	set := NewSparseSet(10)
+	for _, v := range []int{1, 3, 5, 7} {
+		set.Add(v)
+	}
+
+	r := rand.New(rand.NewSource(3))
+	drainRandom(t, 4, func() (int, error) { return set.PopRandom(r) })
+}
+
+func TestPopRandomIsUniform(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		set := growSetOf(4, 0, 1, 2, 3)
+		v, _ := set.PopRandom(r)
+		counts[v]++
+	}
+
+	for v, c := range counts {
+		assert(t, c > 850 && c < 1150, "value %v popped %v times out of 4000", v, c)
+	}
+}
+
+func TestGrowSetPopOrder(t *testing.T) {
+	set := growSetOf(10, 3, 1, 4)
+	order := drainRandom(t, 3, set.Pop)
+	assert(t, slices.Equal(order, []int{4, 1, 3}), "GrowSet should pop in LIFO order, got %v", order)
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+
+	g := growSetOf(10, 2, 5, 7)
+	s := NewShrinkSet(6)
+	s.Remove(1)
+	p := NewSparseSet(10)
+	p.Add(4)
+	p.Add(9)
+
+	for _, set := range []interface {
+		IntSet
+		Random(*rand.Rand) (int, error)
+	}{g, s, p} {
+		size := set.Size()
+		for i := 0; i < 20; i++ {
+			v, err := set.Random(r)
+			assert(t, err == nil, "error is not nil: %v", err)
+			assert(t, set.Contains(v), "random value %v is not a member", v)
+		}
+
+		assert(t, set.Size() == size, "Random should not change the size of the set")
+	}
+
+	_, err := NewGrowSet(3).Random(r)
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestSample(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+
+	g := growSetOf(10, 2, 5, 7, 8, 9)
+	s := NewShrinkSet(8)
+	s.Remove(1)
+	p := NewSparseSet(10)
+	p.Add(4)
+	p.Add(9)
+	p.Add(0)
+
+	for _, set := range []interface {
+		IntSet
+		Sample(int, *rand.Rand) []int
+	}{g, s, p} {
+		before := slices.Clone(set.Values())
+
+		for k := 0; k <= set.Size()+1; k++ {
+			sample := set.Sample(k, r)
+			expected := min(k, set.Size())
+			assert(t, len(sample) == expected, "sample should have %v members, has %v", expected, len(sample))
+
+			seen := make(map[int]bool)
+			for _, v := range sample {
+				assert(t, set.Contains(v), "sampled value %v is not a member", v)
+				assert(t, !seen[v], "duplicate sampled value %v", v)
+				seen[v] = true
+			}
+		}
+
+		assert(t, slices.Equal(before, set.Values()), "Sample should not change the order of the set")
+	}
+}
+
+func TestSampleDuringIteration(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+
+	g := growSetOf(10, 2, 5, 7, 8, 9)
+	visited := 0
+	for range g.All() {
+		assert(t, len(g.Sample(3, r)) == 3, "sample should have 3 members")
+		visited++
+	}
+	assert(t, visited == 5, "should have visited 5 members, visited %v", visited)
+
+	s := NewShrinkSet(6)
+	for range s.All() {
+		s.Sample(4, r)
+	}
+
+	p := NewSparseSet(10)
+	p.AddAll(1, 3, 5)
+	for range p.All() {
+		p.Sample(2, r)
+	}
+
+	// Sampling a fork is a read, so it does not copy the shared storage.
+	fork := g.Fork()
+	fork.Sample(3, r)
+	assert(t, fork.shared, "sampling should not copy shared storage")
+}
+
+func TestSampleForkConcurrent(t *testing.T) {
+	// Forks may be used from different goroutines, so sampling one must
+	// not write to the storage it shares with the other; run with -race.
+	for _, fork := range []func() (IntSet, interface{ Sample(int, *rand.Rand) []int }){
+		func() (IntSet, interface{ Sample(int, *rand.Rand) []int }) {
+			g := NewGrowSet(100)
+			for v := 0; v < 100; v++ {
+				g.Add(v)
+			}
+			return g, g.Fork()
+		},
+		func() (IntSet, interface{ Sample(int, *rand.Rand) []int }) {
+			s := NewShrinkSet(100)
+			s.Remove(7)
+			return s, s.Fork()
+		},
+		func() (IntSet, interface{ Sample(int, *rand.Rand) []int }) {
+			p := NewSparseSet(100)
+			for v := 0; v < 100; v += 2 {
+				p.Add(v)
+			}
+			return p, p.Fork()
+		},
+	} {
+		original, forked := fork()
+		before := slices.Clone(original.Values())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			r := rand.New(rand.NewSource(9))
+			for i := 0; i < 100; i++ {
+				forked.Sample(10, r)
+			}
+		}()
+
+		for i := 0; i < 100; i++ {
+			assert(t, slices.Equal(original.Values(), before), "sampling a fork should not change the original")
+		}
+		<-done
+	}
+}
+
+func TestShuffle(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+
+	g := NewGrowSet(50)
+	p := NewSparseSet(50)
+	for v := 0; v < 50; v++ {
+		g.Add(v)
+		p.Add(v)
+	}
+	s := NewShrinkSet(50)
+
+	for _, set := range []interface {
+		IntSet
+		Shuffle(*rand.Rand)
+	}{g, s, p} {
+		before := slices.Clone(set.Values())
+		set.Shuffle(r)
+
+		after := set.Values()
+		assert(t, !slices.Equal(before, after), "Shuffle should change the order of the set")
+		assert(t, slices.Equal(sortedInto(nil, after), before), "Shuffle should not change the members of the set")
+		for _, v := range before {
+			assert(t, set.Contains(v), "%v should still be a member", v)
+		}
+
+		var popped []int
+		for set.Size() > 0 {
+			v, _ := set.Pop()
+			popped = append(popped, v)
+		}
+
+		reversed := slices.Clone(before)
+		slices.Reverse(reversed)
+		assert(t, !slices.Equal(popped, before) && !slices.Equal(popped, reversed), "Pop order should be randomized")
+		assert(t, slices.Equal(sortedInto(nil, popped), before), "every member should be popped once")
+	}
+}
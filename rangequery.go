@@ -0,0 +1,194 @@
+package intset
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// Calls visit with each member from lo up to, but not including, hi of
+// a set of size members, all between lowest and limit. Values in the
+// range are tested in turn with contains when there are fewer of them
+// than members, and otherwise every member is visited with all, so this
+// takes O(min(hi - lo, k)) time, where k is size. Returns true if the
+// members were visited in increasing order.
+func eachInRange(lo, hi, lowest, limit, size int, contains func(int) bool, all iter.Seq[int], visit func(int)) bool {
+	lo, hi = max(lo, lowest), min(hi, limit)
+	if lo >= hi || size == 0 {
+		return true
+	}
+
+	if hi-lo <= size {
+		for v := lo; v < hi; v++ {
+			if contains(v) {
+				visit(v)
+			}
+		}
+
+		return true
+	}
+
+	for v := range all {
+		if v >= lo && v < hi {
+			visit(v)
+		}
+	}
+
+	return false
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order, in the manner of SortedValues.
+func valuesInRange(dst []int, lo, hi, lowest, limit, size int, contains func(int) bool, all iter.Seq[int]) []int {
+	dst = dst[:0]
+	sorted := eachInRange(lo, hi, lowest, limit, size, contains, all, func(v int) {
+		dst = append(dst, v)
+	})
+
+	if !sorted {
+		slices.Sort(dst)
+	}
+
+	return dst
+}
+
+// Returns the number of members of the set from lo up to, but not
+// including, hi.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set.
+func (g *GrowSet) CountInRange(lo, hi int) int {
+	lowest, limit := (*set)(g).bounds()
+	count := 0
+	eachInRange(lo, hi, lowest, limit, g.n, g.Contains, g.All(), func(int) { count++ })
+	return count
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set,
+// plus the time to sort the result if the set is smaller than the range.
+func (g *GrowSet) ValuesInRange(lo, hi int, dst []int) []int {
+	lowest, limit := (*set)(g).bounds()
+	return valuesInRange(dst, lo, hi, lowest, limit, g.n, g.Contains, g.All())
+}
+
+// Returns the number of members of the set from lo up to, but not
+// including, hi.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set.
+func (s *ShrinkSet) CountInRange(lo, hi int) int {
+	lowest, limit := (*set)(s).bounds()
+	count := 0
+	eachInRange(lo, hi, lowest, limit, s.n, s.Contains, s.All(), func(int) { count++ })
+	return count
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set,
+// plus the time to sort the result if the set is smaller than the range.
+func (s *ShrinkSet) ValuesInRange(lo, hi int, dst []int) []int {
+	lowest, limit := (*set)(s).bounds()
+	return valuesInRange(dst, lo, hi, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the number of members of the set from lo up to, but not
+// including, hi.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set.
+func (s *SparseSet) CountInRange(lo, hi int) int {
+	lowest, limit := (*set)(s).bounds()
+	count := 0
+	eachInRange(lo, hi, lowest, limit, s.n, s.Contains, s.All(), func(int) { count++ })
+	return count
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(min(hi - lo, k)) time, where k is the size of the set,
+// plus the time to sort the result if the set is smaller than the range.
+func (s *SparseSet) ValuesInRange(lo, hi int, dst []int) []int {
+	lowest, limit := (*set)(s).bounds()
+	return valuesInRange(dst, lo, hi, lowest, limit, s.n, s.Contains, s.All())
+}
+
+// Returns the number of members of the set from lo up to, but not
+// including, hi.
+// This takes O(n/64) time, where n == capacity.
+func (b *BitSet) CountInRange(lo, hi int) int {
+	lo = max(lo, 0)
+	if lo >= hi {
+		return 0
+	}
+
+	return b.Rank(hi-1) - b.Rank(lo-1)
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O((hi - lo)/64 + m) time, where m is the number of members
+// returned.
+func (b *BitSet) ValuesInRange(lo, hi int, dst []int) []int {
+	dst = dst[:0]
+	hi = min(hi, b.capacity)
+	for v := b.next(0, lo); v >= 0 && v < hi; v = b.next(0, v+1) {
+		dst = append(dst, v)
+	}
+
+	return dst
+}
+
+// Returns the number of members of the set from lo up to, but not
+// including, hi.
+// This takes the time of two calls to Rank.
+func (f *FrozenSet) CountInRange(lo, hi int) int {
+	if lo >= hi {
+		return 0
+	}
+
+	// There is nothing below math.MinInt, and lo - 1 would wrap.
+	if lo == math.MinInt {
+		return f.Rank(hi - 1)
+	}
+
+	return f.Rank(hi-1) - f.Rank(lo-1)
+}
+
+// Returns the members of the set from lo up to, but not including, hi,
+// in increasing order.
+// The members are written into dst, which is grown only if it is too
+// small to hold them, so passing a slice with enough capacity makes
+// this allocation-free. dst may be nil.
+// This takes O(log k + m) time, where k is the size of the set and m is
+// the number of members returned, when the members are stored as a
+// slice, and O((hi - lo)/64 + m) time when they are stored as a bitmap.
+func (f *FrozenSet) ValuesInRange(lo, hi int, dst []int) []int {
+	dst = dst[:0]
+	if f.bitmap == nil {
+		start, _ := slices.BinarySearch(f.sorted, lo)
+		end, _ := slices.BinarySearch(f.sorted, hi)
+		return append(dst, f.sorted[start:max(start, end)]...)
+	}
+
+	// The offset is the smallest member, so starting there when lo is no
+	// greater keeps lo - 1 from wrapping.
+	v, ok := f.offset, true
+	if lo > f.offset {
+		v, ok = f.NextAfter(lo - 1)
+	}
+
+	for ; ok && v < hi; v, ok = f.NextAfter(v) {
+		dst = append(dst, v)
+	}
+
+	return dst
+}
@@ -0,0 +1,89 @@
+package intset
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRangeQueries(t *testing.T) {
+	type rangeQueries interface {
+		CountInRange(lo, hi int) int
+		ValuesInRange(lo, hi int, dst []int) []int
+	}
+
+	for trial := 0; trial < 20; trial++ {
+		grow := NewGrowSetRange(-20, 200)
+		sparse := NewSparseSetRange(-20, 200)
+		shrink := NewShrinkSetRange(-20, 200)
+		shrink.RemoveRange(-20, 200)
+		bitset := NewBitSet(200)
+
+		for i := rand.Intn(150); i > 0; i-- {
+			v := rand.Intn(200)
+			if trial%2 == 0 {
+				v = rand.Intn(20) + 50
+			}
+
+			grow.Add(v)
+			sparse.Add(v)
+			shrink.Add(v)
+			bitset.Add(v)
+		}
+
+		values := bitset.Values()
+		sets := map[string]rangeQueries{
+			"GrowSet":   grow,
+			"SparseSet": sparse,
+			"ShrinkSet": shrink,
+			"BitSet":    bitset,
+			"FrozenSet": grow.Freeze(),
+		}
+
+		var dst []int
+		for _, r := range [][2]int{{-30, 300}, {0, 0}, {10, 5}, {50, 60}, {-5, 3}, {199, 250}, {55, 56}} {
+			lo, hi := r[0], r[1]
+			var want []int
+			for _, v := range values {
+				if v >= lo && v < hi {
+					want = append(want, v)
+				}
+			}
+
+			for name, s := range sets {
+				count := s.CountInRange(lo, hi)
+				assert(t, count == len(want), "%v: count in [%v, %v) should be %v, is %v", name, lo, hi, len(want), count)
+				dst = s.ValuesInRange(lo, hi, dst)
+				assert(t, slices.Equal(dst, want), "%v: values in [%v, %v) should be %v, are %v", name, lo, hi, want, dst)
+			}
+		}
+	}
+}
+
+func TestRangeQueryExtremes(t *testing.T) {
+	bitset := NewBitSet(100)
+	bitset.Add(3)
+	bitset.Add(7)
+	count := bitset.CountInRange(math.MinInt, 10)
+	assert(t, count == 2, "bitset should have 2 members below 10, not %v", count)
+	count = bitset.CountInRange(math.MinInt, math.MaxInt)
+	assert(t, count == 2, "bitset should have 2 members, not %v", count)
+
+	grow := NewGrowSetRange(-100, 0)
+	grow.AddRange(-100, 0)
+	frozen := grow.Freeze()
+	assert(t, frozen.bitmap != nil, "frozen set should be stored as a bitmap")
+
+	values := frozen.ValuesInRange(math.MinInt, -95, nil)
+	assert(t, slices.Equal(values, []int{-100, -99, -98, -97, -96}), "values should be [-100 ... -96], not %v", values)
+	count = frozen.CountInRange(math.MinInt, -95)
+	assert(t, count == 5, "frozen set should have 5 members below -95, not %v", count)
+
+	sorted := NewFrozenSet(growSetOf(100000, 2, 50, 99999))
+	assert(t, sorted.bitmap == nil, "frozen set should be stored as a slice")
+	count = sorted.CountInRange(math.MinInt, 60)
+	assert(t, count == 2, "frozen set should have 2 members below 60, not %v", count)
+	values = sorted.ValuesInRange(math.MinInt, math.MaxInt, nil)
+	assert(t, slices.Equal(values, []int{2, 50, 99999}), "values should be [2 50 99999], not %v", values)
+}
@@ -0,0 +1,110 @@
+package intset
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Returns the number of bits set in words at positions up to and
+// including i.
+func bitmapRank(words []uint64, i int) int {
+	if i < 0 {
+		return 0
+	}
+
+	count := 0
+	last := min(i/64, len(words))
+	for _, word := range words[:last] {
+		count += bits.OnesCount64(word)
+	}
+
+	if last < len(words) {
+		count += bits.OnesCount64(words[last] & (^uint64(0) >> (63 - i%64)))
+	}
+
+	return count
+}
+
+// Returns the position of the bit in words that has k bits set before
+// it, which must exist.
+func bitmapSelect(words []uint64, k int) int {
+	w := 0
+	for count := bits.OnesCount64(words[w]); k >= count; count = bits.OnesCount64(words[w]) {
+		k -= count
+		w++
+	}
+
+	word := words[w]
+	for ; k > 0; k-- {
+		word &= word - 1
+	}
+
+	return 64*w + bits.TrailingZeros64(word)
+}
+
+// Returns the number of members of the set less than or equal to value.
+// This takes O(n/64) time, where n == capacity.
+func (b *BitSet) Rank(value int) int {
+	return bitmapRank(b.words, value)
+}
+
+// Returns the member of the set that has k members smaller than it, so
+// that Select(0) is the smallest member and Select(Size() - 1) the
+// largest.
+// This takes O(n/64) time, where n == capacity.
+// If k is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (b *BitSet) Select(k int) (int, error) {
+	if k < 0 || k >= b.n {
+		return 0, outOfRange(k, 0, b.n)
+	}
+
+	return bitmapSelect(b.words, k), nil
+}
+
+// Returns the number of members of the set less than or equal to value.
+// This takes O(log k) time, where k is the size of the set, when the
+// members are stored as a slice, and O(r/64) time, where r is the range
+// of the members, when they are stored as a bitmap.
+func (f *FrozenSet) Rank(value int) int {
+	if f.bitmap != nil {
+		// Values outside the bitmap are answered without subtracting the
+		// offset, which could overflow.
+		if value < f.offset {
+			return 0
+		}
+
+		if uint(value)-uint(f.offset) >= uint(64*len(f.bitmap)) {
+			return f.n
+		}
+
+		return bitmapRank(f.bitmap, value-f.offset)
+	}
+
+	i, found := slices.BinarySearch(f.sorted, value)
+	if found {
+		i++
+	}
+
+	return i
+}
+
+// Returns the member of the set that has k members smaller than it, so
+// that Select(0) is the smallest member and Select(Size() - 1) the
+// largest.
+// This takes O(1) time when the members are stored as a slice, and
+// O(r/64) time, where r is the range of the members, when they are
+// stored as a bitmap.
+// If k is less than zero or not less than the size of the set, the
+// result will be 0 and error will be ErrValueOutOfRange.
+func (f *FrozenSet) Select(k int) (int, error) {
+	if k < 0 || k >= f.n {
+		return 0, outOfRange(k, 0, f.n)
+	}
+
+	if f.bitmap != nil {
+		return f.offset + bitmapSelect(f.bitmap, k), nil
+	}
+
+	return f.sorted[k], nil
+}
@@ -0,0 +1,74 @@
+package intset
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRankSelect(t *testing.T) {
+	const capacity = 300
+	for trial := 0; trial < 20; trial++ {
+		b := NewBitSet(capacity)
+		g := NewGrowSet(capacity)
+		for i := rand.Intn(capacity); i > 0; i-- {
+			v := rand.Intn(capacity)
+			if trial%2 == 0 {
+				v = rand.Intn(capacity/10) + 100
+			}
+
+			b.Add(v)
+			g.Add(v)
+		}
+
+		f := g.Freeze()
+		values := b.Values()
+		for v := -1; v <= capacity; v++ {
+			want, found := slices.BinarySearch(values, v)
+			if found {
+				want++
+			}
+
+			assert(t, b.Rank(v) == want, "BitSet rank of %v should be %v, is %v", v, want, b.Rank(v))
+			assert(t, f.Rank(v) == want, "FrozenSet rank of %v should be %v, is %v", v, want, f.Rank(v))
+		}
+
+		for k, want := range values {
+			got, err := b.Select(k)
+			assert(t, err == nil && got == want, "BitSet select %v should be %v, is %v", k, want, got)
+			got, err = f.Select(k)
+			assert(t, err == nil && got == want, "FrozenSet select %v should be %v, is %v", k, want, got)
+		}
+
+		_, err := b.Select(len(values))
+		assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+		_, err = f.Select(-1)
+		assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+	}
+}
+
+func TestFrozenSetRankExtremes(t *testing.T) {
+	grow := NewGrowSetRange(-100, 0)
+	grow.AddRange(-100, 0)
+	frozen := grow.Freeze()
+	assert(t, frozen.bitmap != nil, "frozen set should be stored as a bitmap")
+
+	for _, c := range []struct{ value, rank int }{
+		{math.MinInt, 0}, {-101, 0}, {-100, 1}, {-1, 100}, {0, 100}, {math.MaxInt, 100},
+	} {
+		rank := frozen.Rank(c.value)
+		assert(t, rank == c.rank, "rank of %v should be %v, not %v", c.value, c.rank, rank)
+	}
+
+	count := frozen.CountInRange(-100, math.MaxInt)
+	assert(t, count == 100, "frozen set should have 100 members, not %v", count)
+
+	edges := NewFrozenSet(sliceSet{math.MaxInt - 2, math.MaxInt - 1, math.MaxInt})
+	assert(t, edges.bitmap != nil, "frozen set should be stored as a bitmap")
+	rank := edges.Rank(math.MaxInt)
+	assert(t, rank == 3, "rank of math.MaxInt should be 3, not %v", rank)
+	rank = edges.Rank(math.MinInt)
+	assert(t, rank == 0, "rank of math.MinInt should be 0, not %v", rank)
+}
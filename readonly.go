@@ -0,0 +1,59 @@
+package intset
+
+import (
+	"iter"
+	"slices"
+)
+
+// A ReadOnlySet is a view of another set that exposes only the methods
+// that inspect it, so a set can be handed to code that should not
+// modify it. The view reflects later changes made through the original
+// set. Unlike a FrozenSet it is not a copy, and so is not safe to use
+// while the original is being modified concurrently.
+type ReadOnlySet struct {
+	set readable
+}
+
+// The methods a set must have to be viewed through a ReadOnlySet.
+type readable interface {
+	Contains(value int) bool
+	Size() int
+	Values() []int
+	All() iter.Seq[int]
+}
+
+// Returns a read-only view of the set.
+func (g *GrowSet) AsReadOnly() ReadOnlySet {
+	return ReadOnlySet{g}
+}
+
+// Returns a read-only view of the set.
+func (s *ShrinkSet) AsReadOnly() ReadOnlySet {
+	return ReadOnlySet{s}
+}
+
+// Returns a read-only view of the set.
+func (s *SparseSet) AsReadOnly() ReadOnlySet {
+	return ReadOnlySet{s}
+}
+
+// Returns true if value is a member of the set.
+func (r ReadOnlySet) Contains(value int) bool {
+	return r.set.Contains(value)
+}
+
+// Returns the size of the set.
+func (r ReadOnlySet) Size() int {
+	return r.set.Size()
+}
+
+// Returns a newly allocated slice containing the members of the set.
+// The slice is a copy, so modifying it does not modify the set.
+func (r ReadOnlySet) Values() []int {
+	return slices.Clone(r.set.Values())
+}
+
+// Returns an iterator over the members of the set.
+func (r ReadOnlySet) All() iter.Seq[int] {
+	return r.set.All()
+}
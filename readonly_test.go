@@ -0,0 +1,34 @@
+package intset
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReadOnlySet(t *testing.T) {
+	g := growSetOf(10, 1, 2, 3)
+	view := g.AsReadOnly()
+
+	assert(t, view.Size() == 3, "view size should be 3")
+	assert(t, view.Contains(2) && !view.Contains(4), "view membership is wrong")
+
+	g.Add(4)
+	assert(t, view.Contains(4) && view.Size() == 4, "view should reflect changes to the set")
+
+	values := view.Values()
+	values[0] = 9
+	assert(t, !g.Contains(9), "modifying values should not modify the set")
+
+	var all []int
+	for v := range view.All() {
+		all = append(all, v)
+	}
+	slices.Sort(all)
+	assert(t, slices.Equal(all, []int{1, 2, 3, 4}), "All should visit every member")
+
+	s := NewShrinkSet(3).AsReadOnly()
+	assert(t, s.Size() == 3, "ShrinkSet view size should be 3")
+
+	p := NewSparseSet(3).AsReadOnly()
+	assert(t, p.Size() == 0, "SparseSet view size should be 0")
+}
@@ -0,0 +1,78 @@
+package intset
+
+// Replaces old with replacement in s, in the same position of the dense
+// array. The caller has checked that old is a member, and that
+// replacement is in range and not a member.
+func (s *set) replace(old, replacement int) {
+	s.own()
+	i := s.sparse[old-s.offset]
+	s.dense[i] = replacement
+	s.sparse[replacement-s.offset] = i
+	s.mods++
+}
+
+// Removes old from the set and adds replacement in its place, in O(1)
+// time. The replacement takes over the position of old in the set's
+// internal ordering, so data kept in parallel with the dense array
+// stays in step. The result is true if the replacement was made, and
+// false if old is not a member or replacement already is, in which case
+// the set is unchanged. If replacement is too small or too large to be
+// stored in the set, the result will be false and error will be
+// ErrValueOutOfRange.
+func (g *GrowSet) Replace(old, replacement int) (bool, error) {
+	if !(*set)(g).inRange(replacement) {
+		return false, (*set)(g).outOfRange(replacement)
+	}
+
+	if !g.Contains(old) || g.Contains(replacement) {
+		return false, nil
+	}
+
+	(*set)(g).replace(old, replacement)
+	g.stale = g.stale || old == g.min || old == g.max
+	g.extend(replacement)
+	return true, nil
+}
+
+// Removes old from the set and adds replacement in its place, in O(1)
+// time. The replacement takes over the position of old in the set's
+// internal ordering, and old takes over the position of replacement
+// among the removed values. The result is true if the replacement was
+// made, and false if old is not a member or replacement already is, in
+// which case the set is unchanged. If replacement is too small or too
+// large to have been in the set, the result will be false and error
+// will be ErrValueOutOfRange.
+func (s *ShrinkSet) Replace(old, replacement int) (bool, error) {
+	if !(*set)(s).inRange(replacement) {
+		return false, (*set)(s).outOfRange(replacement)
+	}
+
+	if !s.Contains(old) || s.Contains(replacement) {
+		return false, nil
+	}
+
+	s.extend(replacement)
+	s.swap(s.indexOf(old), s.indexOf(replacement))
+	return true, nil
+}
+
+// Removes old from the set and adds replacement in its place, in O(1)
+// time. The replacement takes over the position of old in the set's
+// internal ordering, so data kept in parallel with the dense array
+// stays in step. The result is true if the replacement was made, and
+// false if old is not a member or replacement already is, in which case
+// the set is unchanged. If replacement is too small or too large to be
+// stored in the set, the result will be false and error will be
+// ErrValueOutOfRange.
+func (s *SparseSet) Replace(old, replacement int) (bool, error) {
+	if !(*set)(s).inRange(replacement) {
+		return false, (*set)(s).outOfRange(replacement)
+	}
+
+	if !s.Contains(old) || s.Contains(replacement) {
+		return false, nil
+	}
+
+	(*set)(s).replace(old, replacement)
+	return true, nil
+}
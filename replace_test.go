@@ -0,0 +1,54 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestGrowSetReplace(t *testing.T) {
+	g := growSetOf(10, 1, 5, 9)
+	before := slices.Index(g.Values(), 5)
+
+	replaced, err := g.Replace(5, 7)
+	assert(t, replaced && err == nil, "5 should have been replaced")
+	assert(t, slices.Index(g.Values(), 7) == before, "7 should take the position of 5")
+	assertMembers(t, g.Contains, g.Size(), 10, 1, 7, 9)
+
+	replaced, _ = g.Replace(5, 8)
+	assert(t, !replaced, "5 is no longer a member")
+	replaced, _ = g.Replace(1, 9)
+	assert(t, !replaced, "9 is already a member")
+
+	replaced, err = g.Replace(1, 10)
+	assert(t, !replaced && errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	g.Replace(9, 0)
+	max, _ := g.Max()
+	min, _ := g.Min()
+	assert(t, min == 0 && max == 7, "extremes should follow the replacement")
+}
+
+func TestShrinkSetReplace(t *testing.T) {
+	s := NewShrinkSet(5)
+	s.Remove(3)
+	before := slices.Index(s.Values(), 1)
+
+	replaced, err := s.Replace(1, 3)
+	assert(t, replaced && err == nil, "1 should have been replaced")
+	assert(t, slices.Index(s.Values(), 3) == before, "3 should take the position of 1")
+	assertMembers(t, s.Contains, s.Size(), 5, 0, 2, 3, 4)
+
+	s.Refill()
+	assert(t, s.Size() == 5, "refill should restore every value")
+}
+
+func TestSparseSetReplace(t *testing.T) {
+	s := NewSparseSet(5)
+	s.AddAll(0, 2)
+
+	replaced, err := s.Replace(0, 4)
+	assert(t, replaced && err == nil, "0 should have been replaced")
+	assert(t, s.Values()[0] == 4, "4 should take the position of 0")
+	assertMembers(t, s.Contains, s.Size(), 5, 2, 4)
+}
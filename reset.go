@@ -0,0 +1,59 @@
+package intset
+
+// Repurposes s to hold the integers less than capacity, with no
+// members. Its arrays are reused if they are large enough and not shared
+// with a fork, and otherwise replaced. If zero is true, reused arrays
+// are cleared, as sets that are initialized lazily require.
+func (s *set) reset(capacity int, zero bool) {
+	validateCapacity(capacity)
+	mods := s.mods + uint(s.n) + 1
+	if s.sparse == nil || s.shared || capacity > cap(s.sparse) || capacity > cap(s.dense) {
+		*s = set{sparse: make([]int, capacity, capacity), dense: make([]int, capacity, capacity), mods: mods}
+		return
+	}
+
+	sparse, dense := s.sparse[:capacity], s.dense[:capacity]
+	if zero {
+		clear(sparse)
+		clear(dense)
+	}
+
+	*s = set{sparse: sparse, dense: dense, mods: mods}
+}
+
+// Empties the set and makes it able to store the integers less than
+// capacity, as if it had just been created by NewGrowSet. The existing
+// storage is reused if it is large enough, so a set kept in a sync.Pool
+// can be recycled without allocating. A set created over a range starts
+// at zero after being reset.
+// This takes O(1) time unless the storage must be replaced.
+// It panics if capacity is negative.
+func (g *GrowSet) Reset(capacity int) {
+	(*set)(g).reset(capacity, false)
+}
+
+// Refills the set and makes it able to store the integers less than
+// capacity, as if it had just been created by NewShrinkSet. The existing
+// storage is reused if it is large enough, so a set kept in a sync.Pool
+// can be recycled without allocating. A set created over a range starts
+// at zero after being reset.
+// The reused storage must be cleared for the set to be initialized
+// lazily again, so this takes O(n) time, where n == capacity.
+// It panics if capacity is negative.
+func (s *ShrinkSet) Reset(capacity int) {
+	(*set)(s).reset(capacity, true)
+	s.n = capacity
+	s.lazy = true
+	s.min, s.max = 0, capacity-1
+}
+
+// Empties the set and makes it able to store the integers less than
+// capacity, as if it had just been created by NewSparseSet. The existing
+// storage is reused if it is large enough, so a set kept in a sync.Pool
+// can be recycled without allocating. A set created over a range starts
+// at zero after being reset.
+// This takes O(1) time unless the storage must be replaced.
+// It panics if capacity is negative.
+func (s *SparseSet) Reset(capacity int) {
+	(*set)(s).reset(capacity, false)
+}
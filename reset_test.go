@@ -0,0 +1,56 @@
+package intset
+
+import (
+	"testing"
+)
+
+func TestGrowSetReset(t *testing.T) {
+	set := NewGrowSetRange(-5, 100)
+	set.AddAll(-5, 3, 99)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		set.Reset(50)
+	})
+
+	assert(t, allocs == 0, "reset should not allocate, got %v", allocs)
+	assert(t, set.Size() == 0 && set.Capacity() == 50, "set should be empty with capacity 50")
+	assert(t, !set.Contains(3), "set should not contain 3")
+	assert(t, set.Add(0) == nil && set.Add(49) == nil, "set should hold 0 through 49")
+
+	set.Reset(200)
+	assert(t, set.Capacity() == 200 && set.Size() == 0, "set should have grown to 200")
+	assert(t, set.Add(199) == nil, "set should hold 199")
+}
+
+func TestShrinkSetReset(t *testing.T) {
+	set := NewShrinkSet(100)
+	set.RemoveAll(1, 2, 3)
+	set.Values()
+	set.Invert()
+
+	allocs := testing.AllocsPerRun(10, func() {
+		set.Reset(10)
+	})
+
+	assert(t, allocs == 0, "reset should not allocate, got %v", allocs)
+	assert(t, set.Size() == 10 && set.Capacity() == 10, "set should be full with capacity 10")
+	assertMembers(t, set.Contains, set.Size(), 10, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+
+	min, _ := set.Min()
+	max, _ := set.Max()
+	assert(t, min == 0 && max == 9, "wrong bounds %v, %v", min, max)
+}
+
+func TestSparseSetReset(t *testing.T) {
+	set := NewSparseSet(100)
+	set.AddAll(1, 50, 99)
+	fork := set.Fork()
+
+	set.Reset(100)
+	assert(t, set.Size() == 0, "set should be empty")
+	assert(t, fork.Size() == 3 && fork.Contains(50), "fork should be unaffected")
+
+	var zero SparseSet
+	zero.Reset(0)
+	assert(t, zero.Add(0) != ErrUninitialized, "reset zero value should be initialized")
+}
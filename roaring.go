@@ -0,0 +1,235 @@
+package intset
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// These functions read and write the portable serialization format
+// shared by the Roaring bitmap implementations for Java, C, Go, and
+// others, as described at https://github.com/RoaringBitmap/RoaringFormatSpec.
+//
+// A Roaring bitmap splits 32-bit values into containers by their upper
+// 16 bits. Each container holds the lower 16 bits of its members either
+// as a sorted array, as a 65536-bit bitmap, or as a list of runs.
+// Writing only produces array and bitmap containers; reading accepts
+// all three.
+const (
+	roaringCookieNoRuns    = 12346
+	roaringCookieRuns      = 12347
+	roaringNoOffsetLimit   = 4
+	roaringArrayLimit      = 4096
+	roaringBitmapSizeBytes = 8192
+)
+
+// Returns the Roaring serialization of the members of s.
+// If s has a member that is negative or larger than math.MaxUint32, the
+// result will be nil and error will be ErrValueOutOfRange.
+// This takes O(k log k) time, where k is the size of the set.
+func MarshalRoaring(s IntSet) ([]byte, error) {
+	values := slices.Clone(s.Values())
+	slices.Sort(values)
+	if len(values) > 0 && values[0] < 0 {
+		return nil, outOfRange(values[0], 0, math.MaxUint32+1)
+	}
+
+	if len(values) > 0 && values[len(values)-1] > math.MaxUint32 {
+		return nil, outOfRange(values[len(values)-1], 0, math.MaxUint32+1)
+	}
+
+	// Split the values into one group per container.
+	var groups [][]int
+	for len(values) > 0 {
+		key := values[0] >> 16
+		end := 1
+		for end < len(values) && values[end]>>16 == key {
+			end++
+		}
+
+		groups = append(groups, values[:end])
+		values = values[end:]
+	}
+
+	b := binary.LittleEndian.AppendUint32(nil, roaringCookieNoRuns)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(groups)))
+	for _, group := range groups {
+		b = binary.LittleEndian.AppendUint16(b, uint16(group[0]>>16))
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(group)-1))
+	}
+
+	offset := len(b) + 4*len(groups)
+	for _, group := range groups {
+		b = binary.LittleEndian.AppendUint32(b, uint32(offset))
+		if len(group) > roaringArrayLimit {
+			offset += roaringBitmapSizeBytes
+		} else {
+			offset += 2 * len(group)
+		}
+	}
+
+	for _, group := range groups {
+		if len(group) > roaringArrayLimit {
+			var bitmap [roaringBitmapSizeBytes / 8]uint64
+			for _, v := range group {
+				bitmap[(v&0xffff)/64] |= 1 << (v % 64)
+			}
+
+			for _, word := range bitmap {
+				b = binary.LittleEndian.AppendUint64(b, word)
+			}
+		} else {
+			for _, v := range group {
+				b = binary.LittleEndian.AppendUint16(b, uint16(v))
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// Returns a new GrowSet holding the members of a Roaring serialization.
+// The set can hold just the values from the smallest member to the
+// largest, so its size depends on how far apart they are rather than on
+// how large they are.
+// If data is not a valid serialization, or the members are too far apart
+// for the set to have a capacity of at most MaxDecodedCapacity, the
+// result will be nil and error will be ErrInvalidEncoding.
+func UnmarshalRoaring(data []byte) (*GrowSet, error) {
+	// The bounds are found from the runs of members, without expanding
+	// them, so that a short input describing a vast range is rejected
+	// before any work proportional to the range is done.
+	lo, hi := 0, 0
+	empty := true
+	err := walkRoaring(data, func(first, last int) {
+		if empty {
+			lo, hi, empty = first, last+1, false
+		}
+
+		lo, hi = min(lo, first), max(hi, last+1)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if hi-lo > MaxDecodedCapacity {
+		return nil, ErrInvalidEncoding
+	}
+
+	result := NewGrowSetRange(lo, hi)
+	walkRoaring(data, func(first, last int) { result.AddRange(first, last+1) })
+	return result, nil
+}
+
+// Calls visit with the first and last members of each run of members of
+// a Roaring serialization. Run containers are visited a run at a time,
+// and array and bitmap containers a member at a time, so the number of
+// calls is proportional to the length of data.
+// If data is not a valid serialization, ErrInvalidEncoding is
+// returned, possibly after some members have been visited.
+func walkRoaring(data []byte, visit func(first, last int)) error {
+	r := roaringReader{data: data}
+
+	var size int
+	var runs []byte
+	cookie := r.uint32()
+	switch {
+	case cookie == roaringCookieNoRuns:
+		size = int(r.uint32())
+	case cookie&0xffff == roaringCookieRuns:
+		size = int(cookie>>16) + 1
+		runs = r.bytes((size + 7) / 8)
+	default:
+		return ErrInvalidEncoding
+	}
+
+	header := r.bytes(4 * size)
+	if runs == nil || size >= roaringNoOffsetLimit {
+		r.bytes(4 * size)
+	}
+
+	if r.bad {
+		return ErrInvalidEncoding
+	}
+
+	for i := 0; i < size; i++ {
+		key := int(binary.LittleEndian.Uint16(header[4*i:])) << 16
+		cardinality := int(binary.LittleEndian.Uint16(header[4*i+2:])) + 1
+
+		switch {
+		case runs != nil && runs[i/8]&(1<<(i%8)) != 0:
+			count := int(r.uint16())
+			for j := 0; j < count && !r.bad; j++ {
+				start := int(r.uint16())
+				length := int(r.uint16())
+				if start+length > math.MaxUint16 {
+					return ErrInvalidEncoding
+				}
+
+				if !r.bad {
+					visit(key|start, key|(start+length))
+				}
+			}
+		case cardinality > roaringArrayLimit:
+			bitmap := r.bytes(roaringBitmapSizeBytes)
+			for w := 0; w < len(bitmap)/8; w++ {
+				word := binary.LittleEndian.Uint64(bitmap[8*w:])
+				for word != 0 {
+					v := key | (64*w + bits.TrailingZeros64(word))
+					visit(v, v)
+					word &= word - 1
+				}
+			}
+		default:
+			for j := 0; j < cardinality && !r.bad; j++ {
+				v := key | int(r.uint16())
+				visit(v, v)
+			}
+		}
+
+		if r.bad {
+			return ErrInvalidEncoding
+		}
+	}
+
+	return nil
+}
+
+// Reads little-endian values from a byte slice, recording rather than
+// returning any attempt to read past its end.
+type roaringReader struct {
+	data []byte
+	bad  bool
+}
+
+// Returns the next n bytes, or nil if there are fewer than n left.
+func (r *roaringReader) bytes(n int) []byte {
+	if r.bad || n > len(r.data) {
+		r.bad = true
+		return nil
+	}
+
+	result := r.data[:n]
+	r.data = r.data[n:]
+	return result
+}
+
+// Returns the next little-endian uint16, or zero if there is none.
+func (r *roaringReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+
+	return 0
+}
+
+// Returns the next little-endian uint32, or zero if there is none.
+func (r *roaringReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+
+	return 0
+}
@@ -0,0 +1,126 @@
+package intset
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestMarshalRoaring(t *testing.T) {
+	data, err := MarshalRoaring(growSetOf(10, 3, 1, 2))
+	assert(t, err == nil, "error is not nil: %v", err)
+
+	expected := []byte{
+		0x3a, 0x30, 0x00, 0x00, // cookie
+		0x01, 0x00, 0x00, 0x00, // one container
+		0x00, 0x00, 0x02, 0x00, // key 0, cardinality 3
+		0x10, 0x00, 0x00, 0x00, // offset 16
+		0x01, 0x00, 0x02, 0x00, 0x03, 0x00,
+	}
+	assert(t, bytes.Equal(data, expected), "encoded as % x", data)
+}
+
+func TestRoaringRoundTrip(t *testing.T) {
+	set := NewGrowSet(300000)
+	for v := 0; v < 10000; v++ {
+		set.Add(v)
+	}
+
+	for _, v := range []int{65536, 70000, 299999} {
+		set.Add(v)
+	}
+
+	data, err := MarshalRoaring(set)
+	assert(t, err == nil, "error is not nil: %v", err)
+
+	decoded, err := UnmarshalRoaring(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, Equal(decoded, set), "decoded set differs")
+	assert(t, len(decoded.sparse) == 300000, "decoded capacity should be 300000")
+
+	empty, err := MarshalRoaring(NewGrowSet(0))
+	assert(t, err == nil, "error is not nil: %v", err)
+	decoded, err = UnmarshalRoaring(empty)
+	assert(t, err == nil && decoded.Size() == 0, "empty set should round trip")
+}
+
+func TestUnmarshalRoaringRuns(t *testing.T) {
+	data := []byte{
+		0x3b, 0x30, 0x01, 0x00, // cookie, two containers
+		0x01,                   // first container is a run container
+		0x00, 0x00, 0x04, 0x00, // key 0, cardinality 5
+		0x01, 0x00, 0x00, 0x00, // key 1, cardinality 1
+		0x01, 0x00, 0x0a, 0x00, 0x04, 0x00, // one run, 10 through 14
+		0x07, 0x00,
+	}
+
+	decoded, err := UnmarshalRoaring(data)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, decoded.Contains, decoded.Size(), 65544, 10, 11, 12, 13, 14, 65543)
+}
+
+func TestUnmarshalRoaringWideRuns(t *testing.T) {
+	// Every 32-bit value, as one full run in each of 65536 containers. The
+	// span is rejected without visiting the members one at a time.
+	const size = 1 << 16
+	data := binary.LittleEndian.AppendUint32(nil, roaringCookieRuns|(size-1)<<16)
+	data = append(data, bytes.Repeat([]byte{0xff}, size/8)...)
+	for key := 0; key < size; key++ {
+		data = binary.LittleEndian.AppendUint16(data, uint16(key))
+		data = binary.LittleEndian.AppendUint16(data, 0xffff)
+	}
+
+	data = append(data, make([]byte, 4*size)...)
+	for key := 0; key < size; key++ {
+		data = binary.LittleEndian.AppendUint16(data, 1)
+		data = binary.LittleEndian.AppendUint16(data, 0)
+		data = binary.LittleEndian.AppendUint16(data, 0xffff)
+	}
+
+	_, err := UnmarshalRoaring(data)
+	assert(t, err == ErrInvalidEncoding, "members too far apart should fail, got %v", err)
+}
+
+func TestUnmarshalRoaringInvalid(t *testing.T) {
+	valid, _ := MarshalRoaring(growSetOf(10, 3, 1, 2))
+	for _, data := range [][]byte{
+		nil,
+		{0x00, 0x00, 0x00, 0x00},
+		valid[:len(valid)-1],
+		valid[:12],
+	} {
+		_, err := UnmarshalRoaring(data)
+		assert(t, err == ErrInvalidEncoding, "decoding % x should fail, got %v", data, err)
+	}
+
+	// A single large member does not need a large set.
+	data, _ := MarshalRoaring(sliceSet{1<<32 - 1})
+	decoded, err := UnmarshalRoaring(data)
+	assert(t, err == nil && decoded.Contains(1<<32-1) && decoded.Capacity() == 1, "decoding a large member failed: %v", err)
+
+	data, _ = MarshalRoaring(sliceSet{0, 1<<32 - 1})
+	_, err = UnmarshalRoaring(data)
+	assert(t, err == ErrInvalidEncoding, "members too far apart should fail, got %v", err)
+
+	_, err = MarshalRoaring(sliceSet{1 << 32})
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+// A minimal IntSet over a slice, for members too large to store in a
+// real set in a test.
+type sliceSet []int
+
+func (s sliceSet) Contains(value int) bool {
+	for _, v := range s {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s sliceSet) Size() int         { return len(s) }
+func (s sliceSet) Values() []int     { return s }
+func (s sliceSet) Pop() (int, error) { return 0, ErrEmptySet }
@@ -0,0 +1,160 @@
+package intset
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type shard struct {
+	sync.Mutex
+	set *SparseSet
+}
+
+// A ShardedSet is a SparseSet that is safe for concurrent use.
+// The universe of values is partitioned across a number of shards, each
+// with its own lock, so that goroutines working on different values
+// rarely contend with each other. Value n is stored in shard n % shards.
+//
+// All operations take O(1) time, except Pop, which may have to visit
+// every shard to find a member.
+type ShardedSet struct {
+	capacity int
+	size     int64
+	next     uint32
+	shards   []shard
+}
+
+// Allocate a new ShardedSet.
+// The resulting set will be able to store the integers less than
+// capacity, split across the given number of shards.
+// If shards is less than one, a single shard is used.
+func NewShardedSet(capacity int, shards int) *ShardedSet {
+	validateCapacity(capacity)
+	if shards < 1 {
+		shards = 1
+	}
+
+	result := &ShardedSet{
+		capacity: capacity,
+		shards:   make([]shard, shards),
+	}
+
+	for i := range result.shards {
+		result.shards[i].set = NewSparseSet((capacity - i + shards - 1) / shards)
+	}
+
+	return result
+}
+
+// Returns the shard holding value, and value's position within it.
+func (s *ShardedSet) locate(value int) (*shard, int) {
+	return &s.shards[value%len(s.shards)], value / len(s.shards)
+}
+
+// Returns true if value is a member of the set.
+func (s *ShardedSet) Contains(value int) bool {
+	if value < 0 || value >= s.capacity {
+		return false
+	}
+
+	sh, local := s.locate(value)
+	sh.Lock()
+	defer sh.Unlock()
+	return sh.set.Contains(local)
+}
+
+// Returns the size of the set.
+// If the set is being modified concurrently, the result reflects
+// some recent state of the set.
+func (s *ShardedSet) Size() int {
+	return int(atomic.LoadInt64(&s.size))
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *ShardedSet) Capacity() int {
+	return s.capacity
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (s *ShardedSet) Add(value int) error {
+	if value < 0 || value >= s.capacity {
+		return outOfRange(value, 0, s.capacity)
+	}
+
+	sh, local := s.locate(value)
+	sh.Lock()
+	defer sh.Unlock()
+
+	if !sh.set.Contains(local) {
+		sh.set.Add(local)
+		atomic.AddInt64(&s.size, 1)
+	}
+
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+func (s *ShardedSet) Remove(item int) {
+	if item < 0 || item >= s.capacity {
+		return
+	}
+
+	sh, local := s.locate(item)
+	sh.Lock()
+	defer sh.Unlock()
+
+	if sh.set.Contains(local) {
+		sh.set.Remove(local)
+		atomic.AddInt64(&s.size, -1)
+	}
+}
+
+// Remove and return an arbitrary value from the set.
+// Successive calls start at different shards, spreading concurrent
+// callers across the locks.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *ShardedSet) Pop() (int, error) {
+	if len(s.shards) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	start := int(atomic.AddUint32(&s.next, 1) % uint32(len(s.shards)))
+	for i := 0; i < len(s.shards); i++ {
+		index := (start + i) % len(s.shards)
+		sh := &s.shards[index]
+
+		sh.Lock()
+		local, err := sh.set.Pop()
+		if err == nil {
+			atomic.AddInt64(&s.size, -1)
+		}
+		sh.Unlock()
+
+		if err == nil {
+			return local*len(s.shards) + index, nil
+		}
+	}
+
+	return 0, ErrEmptySet
+}
+
+// Returns a newly allocated slice containing the members of the set.
+// Unlike the other set types, this allocates on every call, since the
+// set may be modified concurrently. Each shard is copied atomically, but
+// the set as a whole is not.
+func (s *ShardedSet) Values() []int {
+	result := make([]int, 0, s.Size())
+	for index := range s.shards {
+		sh := &s.shards[index]
+		sh.Lock()
+		for _, local := range sh.set.Values() {
+			result = append(result, local*len(s.shards)+index)
+		}
+		sh.Unlock()
+	}
+
+	return result
+}
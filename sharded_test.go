@@ -0,0 +1,111 @@
+package intset
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestShardedSetAddRemoveContains(t *testing.T) {
+	set := NewShardedSet(10, 3)
+
+	for _, v := range []int{0, 1, 5, 9} {
+		err := set.Add(v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	set.Add(5)
+	set.Remove(1)
+	set.Remove(2)
+
+	assert(t, set.Size() == 3, "set size should be 3")
+
+	for _, v := range []int{0, 5, 9} {
+		assert(t, set.Contains(v), "set should contain %v", v)
+	}
+
+	for _, v := range []int{-1, 1, 2, 3, 4, 6, 7, 8, 10} {
+		assert(t, !set.Contains(v), "set should not contain %v", v)
+	}
+
+	err := set.Add(10)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+}
+
+func TestShardedSetPopAndValues(t *testing.T) {
+	set := NewShardedSet(10, 4)
+	for _, v := range []int{2, 3, 7} {
+		set.Add(v)
+	}
+
+	values := set.Values()
+	assert(t, len(values) == 3, "values should have 3 members")
+	for _, v := range values {
+		assert(t, v == 2 || v == 3 || v == 7, "unknown value in set %v", v)
+	}
+
+	seen := 0
+	for i := 0; i < 3; i++ {
+		popped, err := set.Pop()
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, popped == 2 || popped == 3 || popped == 7, "popped value isn't correct")
+		assert(t, !set.Contains(popped), "set should not contain popped value")
+		seen += popped
+	}
+
+	assert(t, seen == 12, "duplicate popped value")
+	assert(t, set.Size() == 0, "set size should be 0")
+
+	_, err := set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestShardedSetZeroValue(t *testing.T) {
+	var set ShardedSet
+	_, err := set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, set.Size() == 0 && !set.Contains(0) && len(set.Values()) == 0, "zero value should be empty")
+}
+
+func TestShardedSetConcurrent(t *testing.T) {
+	const capacity = 1000
+	set := NewShardedSet(capacity, 8)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for v := w; v < capacity; v += 4 {
+				set.Add(v)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert(t, set.Size() == capacity, "set size should be %v", capacity)
+
+	popped := make([]bool, capacity)
+	var mu sync.Mutex
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v, err := set.Pop()
+				if err != nil {
+					return
+				}
+
+				mu.Lock()
+				popped[v] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for v, ok := range popped {
+		assert(t, ok, "value %v was never popped", v)
+	}
+}
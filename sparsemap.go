@@ -0,0 +1,150 @@
+package intset
+
+import (
+	"iter"
+)
+
+// A SparseMap maps integer keys to values using the same representation
+// as a SparseSet, with the value of each key stored alongside it in the
+// dense array. It supports the following operations with the associated
+// time complexity:
+//
+//   Get(k)       - Return the value of key k, in O(1) time.
+//   Set(k, v)    - Set the value of key k to v, in O(1) time.
+//   Delete(k)    - Remove key k from the map, in O(1) time.
+//   Clear()      - Remove every key from the map, in O(1) time.
+//   Keys()       - Return the keys in the map, in O(1) time.
+//
+// Clear does not overwrite the values that were in the map, so any
+// memory they refer to is kept alive until their slots are reused.
+type SparseMap[V any] struct {
+	n      int
+	sparse []int
+	dense  []int
+	values []V
+}
+
+// Allocate a new SparseMap.
+// The resulting map will be able to store the keys less than capacity.
+func NewSparseMap[V any](capacity int) *SparseMap[V] {
+	validateCapacity(capacity)
+	return &SparseMap[V]{
+		n:      0,
+		sparse: make([]int, capacity, capacity),
+		dense:  make([]int, capacity, capacity),
+		values: make([]V, capacity, capacity),
+	}
+}
+
+// Returns the index of key in the dense array, or -1 if it is not in
+// the map.
+func (m *SparseMap[V]) index(key int) int {
+	if key < 0 || key >= len(m.sparse) {
+		return -1
+	}
+
+	index := m.sparse[key]
+	if index < m.n && m.dense[index] == key {
+		return index
+	}
+
+	return -1
+}
+
+// Returns true if key is in the map.
+func (m *SparseMap[V]) Contains(key int) bool {
+	return m.index(key) >= 0
+}
+
+// Returns the value of key, and true if key is in the map. If it is
+// not, the result will be the zero value of V and false.
+func (m *SparseMap[V]) Get(key int) (V, bool) {
+	if i := m.index(key); i >= 0 {
+		return m.values[i], true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Sets the value of key, adding it to the map if it is not already
+// there.
+// If key is less than zero or too large to be stored in the map,
+// ErrValueOutOfRange is returned, otherwise nil.
+func (m *SparseMap[V]) Set(key int, value V) error {
+	if key < 0 || key >= len(m.sparse) {
+		return outOfRange(key, 0, len(m.sparse))
+	}
+
+	i := m.index(key)
+	if i < 0 {
+		i = m.n
+		m.dense[i] = key
+		m.sparse[key] = i
+		m.n++
+	}
+
+	m.values[i] = value
+	return nil
+}
+
+// Removes key from the map. It is not an error to remove a key that is
+// not in the map.
+func (m *SparseMap[V]) Delete(key int) {
+	i := m.index(key)
+	if i < 0 {
+		return
+	}
+
+	last := m.n - 1
+	m.dense[i] = m.dense[last]
+	m.values[i] = m.values[last]
+	m.sparse[m.dense[i]] = i
+
+	var zero V
+	m.values[last] = zero
+	m.n--
+}
+
+// Removes every key from the map.
+func (m *SparseMap[V]) Clear() {
+	m.n = 0
+}
+
+// Returns the number of keys in the map.
+func (m *SparseMap[V]) Size() int {
+	return m.n
+}
+
+// Returns the number of distinct keys the map is able to store.
+func (m *SparseMap[V]) Capacity() int {
+	return len(m.sparse)
+}
+
+// Returns a slice of the keys in the map.
+// This slice should not be modified.
+func (m *SparseMap[V]) Keys() []int {
+	return m.dense[:m.n]
+}
+
+// Returns a slice of the values in the map, in the same order as the
+// keys returned by Keys. This slice should not be modified, though the
+// values it holds may be.
+func (m *SparseMap[V]) Values() []V {
+	return m.values[:m.n]
+}
+
+// Returns an iterator over the keys and values in the map.
+// Keys are visited from the end of the dense array backwards, so the
+// key being visited may safely be deleted during iteration, and keys
+// added during iteration are not visited.
+// Iteration does not allocate.
+func (m *SparseMap[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for i := m.n - 1; i >= 0; i-- {
+			if i < m.n && !yield(m.dense[i], m.values[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package intset
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSparseMap(t *testing.T) {
+	m := NewSparseMap[string](10)
+
+	for k, v := range map[int]string{1: "one", 4: "four", 9: "nine"} {
+		err := m.Set(k, v)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	m.Set(4, "FOUR")
+	assert(t, m.Size() == 3, "map size should be 3")
+	assert(t, m.Capacity() == 10, "map capacity should be 10")
+
+	v, ok := m.Get(4)
+	assert(t, ok && v == "FOUR", "key 4 should map to FOUR, got %q", v)
+
+	v, ok = m.Get(5)
+	assert(t, !ok && v == "", "key 5 should not be in the map")
+	assert(t, !m.Contains(-1) && !m.Contains(10), "out of range keys should not be in the map")
+
+	err := m.Set(10, "ten")
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	m.Delete(1)
+	m.Delete(1)
+	keys := slices.Clone(m.Keys())
+	slices.Sort(keys)
+	assert(t, slices.Equal(keys, []int{4, 9}), "keys should be [4 9], got %v", keys)
+
+	for i, k := range m.Keys() {
+		v, _ := m.Get(k)
+		assert(t, m.Values()[i] == v, "values should line up with keys")
+	}
+
+	m.Clear()
+	assert(t, m.Size() == 0 && !m.Contains(4), "map should be empty")
+}
+
+func TestSparseMapAll(t *testing.T) {
+	m := NewSparseMap[int](100)
+	for k := 0; k < 100; k += 7 {
+		m.Set(k, k*k)
+	}
+
+	count := 0
+	for k, v := range m.All() {
+		assert(t, v == k*k, "value of %v should be %v", k, k*k)
+		m.Delete(k)
+		count++
+	}
+
+	assert(t, count == 15 && m.Size() == 0, "All should visit every key")
+}
@@ -0,0 +1,97 @@
+package intset
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Sets are stored in a database as a blob holding their binary
+// encoding, as produced by MarshalBinary, so that a set stored in a row
+// can be scanned directly into a set field.
+
+// Returns the source of a scanned blob, or nil for NULL.
+// If src is of any other type, an error is returned.
+func scanBlob(src any) ([]byte, error) {
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return src, nil
+	case string:
+		return []byte(src), nil
+	default:
+		return nil, fmt.Errorf("intset: cannot scan %T into a set", src)
+	}
+}
+
+// Returns the binary encoding of the set, implementing driver.Valuer.
+func (g *GrowSet) Value() (driver.Value, error) {
+	return g.MarshalBinary()
+}
+
+// Replaces the set with one decoded from a blob, implementing
+// sql.Scanner. Scanning NULL leaves the set as the zero value, which
+// contains nothing.
+// If the blob is not a valid encoding, ErrInvalidEncoding is returned
+// and the set is left in an unspecified state.
+func (g *GrowSet) Scan(src any) error {
+	data, err := scanBlob(src)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		*g = GrowSet{}
+		return nil
+	}
+
+	return g.UnmarshalBinary(data)
+}
+
+// Returns the binary encoding of the set, implementing driver.Valuer.
+func (s *ShrinkSet) Value() (driver.Value, error) {
+	return s.MarshalBinary()
+}
+
+// Replaces the set with one decoded from a blob, implementing
+// sql.Scanner. Scanning NULL leaves the set as the zero value, which
+// contains nothing.
+// If the blob is not a valid encoding, ErrInvalidEncoding is returned
+// and the set is left in an unspecified state.
+func (s *ShrinkSet) Scan(src any) error {
+	data, err := scanBlob(src)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		*s = ShrinkSet{}
+		return nil
+	}
+
+	return s.UnmarshalBinary(data)
+}
+
+// Returns the binary encoding of the set, implementing driver.Valuer.
+func (s *SparseSet) Value() (driver.Value, error) {
+	return s.MarshalBinary()
+}
+
+// Replaces the set with one decoded from a blob, implementing
+// sql.Scanner. Scanning NULL leaves the set as the zero value, which
+// contains nothing.
+// If the blob is not a valid encoding, ErrInvalidEncoding is returned
+// and the set is left in an unspecified state.
+func (s *SparseSet) Scan(src any) error {
+	data, err := scanBlob(src)
+	if err != nil {
+		return err
+	}
+
+	if data == nil {
+		*s = SparseSet{}
+		return nil
+	}
+
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,50 @@
+package intset
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*GrowSet)(nil)
+	_ driver.Valuer = (*GrowSet)(nil)
+	_ sql.Scanner   = (*ShrinkSet)(nil)
+	_ driver.Valuer = (*ShrinkSet)(nil)
+	_ sql.Scanner   = (*SparseSet)(nil)
+	_ driver.Valuer = (*SparseSet)(nil)
+)
+
+func TestSQL(t *testing.T) {
+	src := NewSparseSetRange(-10, 90)
+	src.AddAll(-10, 0, 89)
+
+	value, err := src.Value()
+	assert(t, err == nil, "error is not nil: %v", err)
+	_, ok := value.([]byte)
+	assert(t, ok, "value should be a blob, is %T", value)
+
+	var dst SparseSet
+	assert(t, dst.Scan(value) == nil, "error scanning")
+	assertMembers(t, func(v int) bool { return dst.Contains(v - 10) }, dst.Size(), 100, 0, 10, 99)
+	assert(t, dst.Capacity() == 100, "capacity should be 100, is %v", dst.Capacity())
+
+	var grow GrowSet
+	assert(t, grow.Scan(string(value.([]byte))) == nil, "error scanning a string")
+	assert(t, grow.Size() == 3 && grow.Contains(89), "string should decode like a blob")
+
+	assert(t, grow.Scan(nil) == nil, "error scanning NULL")
+	assert(t, grow.Size() == 0 && grow.Capacity() == 0, "NULL should give the zero value")
+
+	shrink := NewShrinkSet(10)
+	shrink.Remove(4)
+	value, _ = shrink.Value()
+	var restored ShrinkSet
+	assert(t, restored.Scan(value) == nil, "error scanning")
+	assertMembers(t, restored.Contains, restored.Size(), 10, 0, 1, 2, 3, 5, 6, 7, 8, 9)
+
+	assert(t, restored.Scan(42) != nil, "scanning an integer should fail")
+	assert(t, restored.Scan([]byte{0xff}) == ErrInvalidEncoding, "error should be ErrInvalidEncoding")
+	hostile := []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x40, 0}
+	assert(t, restored.Scan(hostile) == ErrInvalidEncoding, "a huge capacity should be ErrInvalidEncoding")
+}
@@ -0,0 +1,196 @@
+package intset
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// The part of a StealingSet's dense array owned by one worker. The
+// members of the segment are dense[lo:hi].
+type segment struct {
+	sync.Mutex
+	start  int
+	lo, hi int
+}
+
+// A StealingSet is a set that many goroutines can drain concurrently
+// without contending on a single lock.
+// Its members are stored in one dense array that is split into a
+// segment for each worker, each with its own lock. A worker pops from
+// the back of its own segment, and only when that is empty does it
+// steal from the front of another worker's segment, so workers
+// contend only while stealing. Every member is popped exactly once.
+//
+// Members cannot be added to a StealingSet; it is built from a snapshot
+// of another set, typically a queue of work to be shared out, and the
+// dense and sparse arrays are never written again, so Contains need only
+// lock the segment that would hold its value.
+// Contains takes O(log workers) time, and Pop takes O(1) time unless it
+// has to steal, when it may visit every segment.
+type StealingSet struct {
+	size     int64
+	next     uint32
+	sparse   []int
+	dense    []int
+	segments []segment
+}
+
+// Allocate a new StealingSet containing the members of s, split as
+// evenly as possible between the given number of workers.
+// The resulting set will be able to store the integers less than one
+// more than the largest member of s.
+// If s has a negative member, or one that would need a capacity greater
+// than MaxDecodedCapacity, the result will be nil and error will be
+// ErrValueOutOfRange. If workers is less than one, a single worker is
+// used.
+func NewStealingSet(s IntSet, workers int) (*StealingSet, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	// Members are checked before the capacity is computed from them, so
+	// that it cannot overflow.
+	values := s.Values()
+	capacity := 0
+	for _, v := range values {
+		if v < 0 || v >= MaxDecodedCapacity {
+			return nil, outOfRange(v, 0, MaxDecodedCapacity)
+		}
+
+		capacity = max(capacity, v+1)
+	}
+
+	result := &StealingSet{
+		size:     int64(len(values)),
+		sparse:   make([]int, capacity),
+		dense:    make([]int, len(values)),
+		segments: make([]segment, workers),
+	}
+
+	copy(result.dense, values)
+	for i, v := range result.dense {
+		result.sparse[v] = i
+	}
+
+	for i := range result.segments {
+		seg := &result.segments[i]
+		seg.start = i * len(values) / workers
+		seg.lo, seg.hi = seg.start, (i+1)*len(values)/workers
+	}
+
+	return result, nil
+}
+
+// Returns the number of workers the set was split between.
+func (s *StealingSet) Workers() int {
+	return len(s.segments)
+}
+
+// Returns the segment holding value, and value's position in the dense
+// array. If value was never a member of the set, the segment is nil.
+func (s *StealingSet) locate(value int) (*segment, int) {
+	if value < 0 || value >= len(s.sparse) {
+		return nil, 0
+	}
+
+	index := s.sparse[value]
+	if index >= len(s.dense) || s.dense[index] != value {
+		return nil, 0
+	}
+
+	i := sort.Search(len(s.segments), func(i int) bool {
+		return s.segments[i].start > index
+	})
+
+	return &s.segments[i-1], index
+}
+
+// Returns true if value is a member of the set.
+func (s *StealingSet) Contains(value int) bool {
+	seg, index := s.locate(value)
+	if seg == nil {
+		return false
+	}
+
+	seg.Lock()
+	defer seg.Unlock()
+	return seg.lo <= index && index < seg.hi
+}
+
+// Returns the size of the set.
+// If the set is being modified concurrently, the result reflects
+// some recent state of the set.
+func (s *StealingSet) Size() int {
+	return int(atomic.LoadInt64(&s.size))
+}
+
+// Returns the number of distinct values the set is able to store.
+func (s *StealingSet) Capacity() int {
+	return len(s.sparse)
+}
+
+// Remove and return a value from the given worker's segment, taking the
+// most recently stored member first. If the segment is empty, a value is
+// stolen from the front of the next worker's segment that has one.
+// Worker must be less than Workers().
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *StealingSet) PopWorker(worker int) (int, error) {
+	seg := &s.segments[worker]
+	seg.Lock()
+	if seg.lo < seg.hi {
+		seg.hi--
+		value := s.dense[seg.hi]
+		seg.Unlock()
+
+		atomic.AddInt64(&s.size, -1)
+		return value, nil
+	}
+	seg.Unlock()
+
+	for i := 1; i < len(s.segments); i++ {
+		victim := &s.segments[(worker+i)%len(s.segments)]
+		victim.Lock()
+		if victim.lo < victim.hi {
+			value := s.dense[victim.lo]
+			victim.lo++
+			victim.Unlock()
+
+			atomic.AddInt64(&s.size, -1)
+			return value, nil
+		}
+		victim.Unlock()
+	}
+
+	return 0, ErrEmptySet
+}
+
+// Remove and return an arbitrary value from the set.
+// Successive calls start at different workers' segments, spreading
+// callers that do not have a worker number of their own across the
+// locks.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (s *StealingSet) Pop() (int, error) {
+	if len(s.segments) == 0 {
+		return 0, ErrEmptySet
+	}
+
+	worker := atomic.AddUint32(&s.next, 1) % uint32(len(s.segments))
+	return s.PopWorker(int(worker))
+}
+
+// Returns a newly allocated slice containing the members of the set.
+// Unlike most of the other set types, this allocates on every call,
+// since the set may be modified concurrently. Each segment is copied
+// atomically, but the set as a whole is not.
+func (s *StealingSet) Values() []int {
+	result := make([]int, 0, s.Size())
+	for i := range s.segments {
+		seg := &s.segments[i]
+		seg.Lock()
+		result = append(result, s.dense[seg.lo:seg.hi]...)
+		seg.Unlock()
+	}
+
+	return result
+}
@@ -0,0 +1,110 @@
+package intset
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestStealingSetPopWorker(t *testing.T) {
+	set, err := NewStealingSet(growSetOf(10, 0, 1, 2, 3, 4, 5), 3)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, set.Workers() == 3, "set should have 3 workers")
+	assert(t, set.Size() == 6, "set size should be 6")
+	assert(t, set.Capacity() == 6, "set capacity should be 6")
+
+	// Worker 0 owns 0 and 1, and pops them most recent first.
+	for _, want := range []int{1, 0} {
+		popped, err := set.PopWorker(0)
+		assert(t, err == nil, "error is not nil: %v", err)
+		assert(t, popped == want, "popped %v, expected %v", popped, want)
+		assert(t, !set.Contains(popped), "set should not contain popped value")
+	}
+
+	// Worker 0's segment is empty, so it steals from the front of
+	// worker 1's.
+	popped, err := set.PopWorker(0)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, popped == 2, "popped %v, expected a value stolen from worker 1", popped)
+
+	popped, err = set.PopWorker(1)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, popped == 3, "popped %v, expected 3", popped)
+
+	assert(t, set.Size() == 2, "set size should be 2")
+	values := set.Values()
+	assert(t, len(values) == 2 && values[0] == 4 && values[1] == 5, "values should be [4 5], not %v", values)
+
+	for i := 0; i < 2; i++ {
+		_, err = set.PopWorker(1)
+		assert(t, err == nil, "error is not nil: %v", err)
+	}
+
+	_, err = set.PopWorker(2)
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, err = set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+}
+
+func TestStealingSetNegative(t *testing.T) {
+	_, err := NewStealingSet(NewIntervalSet(), 2)
+	assert(t, err == nil, "error is not nil: %v", err)
+
+	negative := NewIntervalSet()
+	negative.Add(-3)
+	_, err = NewStealingSet(negative, 2)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	for _, v := range []int{math.MaxInt, MaxDecodedCapacity} {
+		huge := NewIntervalSet()
+		huge.Add(v)
+		_, err = NewStealingSet(huge, 2)
+		assert(t, errors.Is(err, ErrValueOutOfRange), "a member of %v should be ErrValueOutOfRange, got %v", v, err)
+	}
+}
+
+func TestStealingSetZeroValue(t *testing.T) {
+	var set StealingSet
+	_, err := set.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	assert(t, set.Size() == 0 && !set.Contains(0) && len(set.Values()) == 0, "zero value should be empty")
+}
+
+func TestStealingSetConcurrent(t *testing.T) {
+	const capacity = 1000
+	source := NewGrowSet(capacity)
+	for v := 0; v < capacity; v++ {
+		source.Add(v)
+	}
+
+	const workers = 4
+	set, _ := NewStealingSet(source, workers)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	popped := make([]int, capacity)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				v, err := set.PopWorker(w)
+				if err != nil {
+					return
+				}
+
+				mu.Lock()
+				popped[v]++
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for v, count := range popped {
+		assert(t, count == 1, "value %v was popped %v times", v, count)
+	}
+
+	assert(t, set.Size() == 0, "set size should be 0")
+}
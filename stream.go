@@ -0,0 +1,228 @@
+package intset
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The streaming format is a five-byte header, made up of the magic
+// string "iset" followed by a format version, and then a sequence of
+// varints. Readers reject versions they do not know.
+//
+// Version 1 is followed by the capacity, the number of members, and the
+// members themselves, all unsigned.
+//
+// Version 2 is used for sets whose smallest value is not zero. It is
+// followed by that smallest value as a signed varint, and then the same
+// fields as version 1, with each member stored relative to the smallest
+// value.
+const (
+	streamMagic         = "iset"
+	streamVersion       = 1
+	streamVersionOffset = 2
+)
+
+// Writes the streaming encoding of a set to w, buffering a small,
+// fixed amount of data at a time. Returns the number of bytes written.
+func writeTo(w io.Writer, offset int, capacity int, values []int) (int64, error) {
+	var buffer [4096]byte
+	var written int64
+
+	b := append(buffer[:0], streamMagic...)
+	if offset == 0 {
+		b = append(b, streamVersion)
+	} else {
+		b = append(b, streamVersionOffset)
+		b = binary.AppendVarint(b, int64(offset))
+	}
+
+	b = binary.AppendUvarint(b, uint64(capacity))
+	b = binary.AppendUvarint(b, uint64(len(values)))
+	for i := 0; ; i++ {
+		if i == len(values) || len(b) > len(buffer)-binary.MaxVarintLen64 {
+			n, err := w.Write(b)
+			written += int64(n)
+			if err != nil || i == len(values) {
+				return written, err
+			}
+
+			b = buffer[:0]
+		}
+
+		b = binary.AppendUvarint(b, uint64(values[i]-offset))
+	}
+}
+
+// An io.ByteReader that counts the bytes it has read.
+type countingReader struct {
+	io.ByteReader
+	count int64
+}
+
+// Reads a byte from the underlying reader, counting it if successful.
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.ByteReader.ReadByte()
+	if err == nil {
+		c.count++
+	}
+
+	return b, err
+}
+
+// Reads the streaming encoding of a set from r, calling create with the
+// smallest value and the capacity of the set and then add with each
+// member. Returns the number of bytes read.
+// If r does not implement io.ByteReader, it is buffered, and may be
+// read past the end of the set. Reaching the end of r before the end of
+// the set, even if nothing was read, is reported as io.ErrUnexpectedEOF
+// rather than io.EOF, which io.ReaderFrom does not return.
+func readFrom(r io.Reader, create func(offset, capacity int), add func(value int) error) (int64, error) {
+	byteReader, ok := r.(io.ByteReader)
+	if !ok {
+		byteReader = bufio.NewReader(r)
+	}
+
+	c := &countingReader{ByteReader: byteReader}
+	err := decodeStream(c, create, add)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return c.count, err
+}
+
+// Decodes the streaming encoding of a set from r. See readFrom.
+func decodeStream(r io.ByteReader, create func(offset, capacity int), add func(value int) error) error {
+	var version byte
+	for i := 0; i <= len(streamMagic); i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		if i < len(streamMagic) && b != streamMagic[i] {
+			return ErrInvalidEncoding
+		}
+
+		version = b
+	}
+
+	var offset int64
+	switch version {
+	case streamVersion:
+	case streamVersionOffset:
+		var err error
+		if offset, err = binary.ReadVarint(r); err != nil {
+			if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+				return io.ErrUnexpectedEOF
+			}
+
+			return ErrInvalidEncoding
+		}
+	default:
+		return ErrInvalidEncoding
+	}
+
+	capacity, err := readUvarint(r, uint64(MaxDecodedCapacity))
+	if err != nil {
+		return err
+	}
+
+	count, err := readUvarint(r, capacity)
+	if err != nil {
+		return err
+	}
+
+	if offset+int64(capacity) < offset {
+		return ErrInvalidEncoding
+	}
+
+	create(int(offset), int(capacity))
+	for ; count > 0; count-- {
+		v, err := readUvarint(r, capacity-1)
+		if err != nil {
+			return err
+		}
+
+		add(int(offset) + int(v))
+	}
+
+	return nil
+}
+
+// Reads an unsigned varint from r, returning ErrInvalidEncoding if it
+// is malformed or larger than max.
+func readUvarint(r io.ByteReader, max uint64) (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	if err != nil || v > max {
+		return 0, ErrInvalidEncoding
+	}
+
+	return v, nil
+}
+
+// Writes the set to w, implementing io.WriterTo. No intermediate
+// encoding of the whole set is built, so this is suitable for very
+// large sets.
+func (g *GrowSet) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, g.offset, len(g.sparse), g.Values())
+}
+
+// Replaces the set with one read from r, implementing io.ReaderFrom.
+// If r does not implement io.ByteReader, it is buffered, and so may be
+// read past the end of the set.
+// If the data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state. If r is empty or ends within
+// the set, io.ErrUnexpectedEOF is returned.
+func (g *GrowSet) ReadFrom(r io.Reader) (int64, error) {
+	return readFrom(r, func(offset, capacity int) { *g = *NewGrowSetRange(offset, offset+capacity) }, g.Add)
+}
+
+// Writes the set to w, implementing io.WriterTo. No intermediate
+// encoding of the whole set is built, so this is suitable for very
+// large sets.
+func (s *ShrinkSet) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, s.offset, len(s.sparse), s.Values())
+}
+
+// Replaces the set with one read from r, implementing io.ReaderFrom.
+// Refilling the decoded set restores every value less than its capacity.
+// If r does not implement io.ByteReader, it is buffered, and so may be
+// read past the end of the set.
+// If the data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state. If r is empty or ends within
+// the set, io.ErrUnexpectedEOF is returned.
+func (s *ShrinkSet) ReadFrom(r io.Reader) (int64, error) {
+	create := func(offset, capacity int) {
+		*s = *NewShrinkSetRange(offset, offset+capacity)
+		s.n = 0
+	}
+
+	return readFrom(r, create, s.Add)
+}
+
+// Writes the set to w, implementing io.WriterTo. No intermediate
+// encoding of the whole set is built, so this is suitable for very
+// large sets.
+func (s *SparseSet) WriteTo(w io.Writer) (int64, error) {
+	return writeTo(w, s.offset, len(s.sparse), s.Values())
+}
+
+// Replaces the set with one read from r, implementing io.ReaderFrom.
+// If r does not implement io.ByteReader, it is buffered, and so may be
+// read past the end of the set.
+// If the data is not a valid encoding, or the set would have a capacity
+// greater than MaxDecodedCapacity, ErrInvalidEncoding is returned and
+// the set is left in an unspecified state. If r is empty or ends within
+// the set, io.ErrUnexpectedEOF is returned.
+func (s *SparseSet) ReadFrom(r io.Reader) (int64, error) {
+	return readFrom(r, func(offset, capacity int) { *s = *NewSparseSetRange(offset, offset+capacity) }, s.Add)
+}
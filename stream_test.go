@@ -0,0 +1,87 @@
+package intset
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestGrowSetWriteToReadFrom(t *testing.T) {
+	set := NewGrowSet(100000)
+	for v := 0; v < 100000; v += 3 {
+		set.Add(v)
+	}
+
+	var buffer bytes.Buffer
+	written, err := set.WriteTo(&buffer)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, written == int64(buffer.Len()), "wrote %v bytes but reported %v", buffer.Len(), written)
+	assert(t, bytes.HasPrefix(buffer.Bytes(), []byte("iset\x01")), "missing header")
+
+	var decoded GrowSet
+	read, err := decoded.ReadFrom(&buffer)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, read == written, "read %v bytes but wrote %v", read, written)
+	assert(t, slices.Equal(decoded.Values(), set.Values()), "decoded values differ")
+}
+
+func TestShrinkSetAndSparseSetWriteToReadFrom(t *testing.T) {
+	s := NewShrinkSet(6)
+	s.Remove(2)
+
+	var buffer bytes.Buffer
+	s.WriteTo(&buffer)
+
+	decoded := NewShrinkSet(0)
+	_, err := decoded.ReadFrom(&buffer)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, decoded.Contains, decoded.Size(), 6, 0, 1, 3, 4, 5)
+
+	p := NewSparseSet(10)
+	p.Add(7)
+	p.WriteTo(&buffer)
+
+	var decodedSparse SparseSet
+	_, err = decodedSparse.ReadFrom(&buffer)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assertMembers(t, decodedSparse.Contains, decodedSparse.Size(), 10, 7)
+}
+
+func TestReadFromInvalid(t *testing.T) {
+	for _, test := range []struct {
+		data string
+		err  error
+	}{
+		{"", io.ErrUnexpectedEOF},
+		{"iset\x01\x80\x80\x80\x80\x80\x80\x80\x80\x40\x00", ErrInvalidEncoding},
+		{"iset", io.ErrUnexpectedEOF},
+		{"isex\x01\x05\x00", ErrInvalidEncoding},
+		{"iset\x03\x05\x00", ErrInvalidEncoding},
+		{"iset\x02", io.ErrUnexpectedEOF},
+		{"iset\x01\x05\x06", ErrInvalidEncoding},
+		{"iset\x01\x05\x01\x05", ErrInvalidEncoding},
+		{"iset\x01\x05\x02\x01", io.ErrUnexpectedEOF},
+	} {
+		var set GrowSet
+		_, err := set.ReadFrom(bytes.NewReader([]byte(test.data)))
+		assert(t, err == test.err, "reading %q should fail with %v, got %v", test.data, test.err, err)
+	}
+}
+
+func TestStreamWithOffset(t *testing.T) {
+	set := NewShrinkSetRange(1000, 1004)
+	set.Remove(1001)
+
+	var buffer bytes.Buffer
+	set.WriteTo(&buffer)
+	assert(t, bytes.HasPrefix(buffer.Bytes(), []byte("iset\x02")), "offset sets should use version 2")
+
+	var decoded ShrinkSet
+	_, err := decoded.ReadFrom(&buffer)
+	assert(t, err == nil, "error is not nil: %v", err)
+	assert(t, decoded.Equal(set), "decoded set differs")
+
+	decoded.Refill()
+	assert(t, decoded.Size() == 4 && decoded.Contains(1001), "refill should restore the range")
+}
@@ -0,0 +1,35 @@
+package intset
+
+// Adds value to the set if it is not a member, and removes it if it
+// is, returning true if value is now a member.
+// If a value is too small or too large to have been in the set, the
+// result will be false and error will be ErrValueOutOfRange.
+func (s *ShrinkSet) Toggle(value int) (bool, error) {
+	if s.Contains(value) {
+		s.Remove(value)
+		return false, nil
+	}
+
+	if err := s.Add(value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Adds value to the set if it is not a member, and removes it if it
+// is, returning true if value is now a member.
+// If a value is too small or too large to be stored in the set, the
+// result will be false and error will be ErrValueOutOfRange.
+func (s *SparseSet) Toggle(value int) (bool, error) {
+	if s.Contains(value) {
+		s.Remove(value)
+		return false, nil
+	}
+
+	if err := s.Add(value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
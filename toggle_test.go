@@ -0,0 +1,27 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToggle(t *testing.T) {
+	s := NewShrinkSet(4)
+	member, err := s.Toggle(2)
+	assert(t, err == nil && !member && !s.Contains(2), "2 should have been removed")
+
+	member, err = s.Toggle(2)
+	assert(t, err == nil && member && s.Contains(2), "2 should have been added back")
+
+	_, err = s.Toggle(4)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	p := NewSparseSet(4)
+	for i := 0; i < 3; i++ {
+		p.Toggle(1)
+	}
+	assertMembers(t, p.Contains, p.Size(), 4, 1)
+
+	member, err = p.Toggle(-1)
+	assert(t, errors.Is(err, ErrValueOutOfRange) && !member, "error should be ErrValueOutOfRange")
+}
@@ -0,0 +1,77 @@
+package intset
+
+// Adds value to the set, like Add, but panics if value is too small or
+// too large to be stored in the set. This suits callers that have
+// already validated their values and would otherwise discard the error.
+func (g *GrowSet) MustAdd(value int) {
+	if err := g.Add(value); err != nil {
+		panic(err)
+	}
+}
+
+// Adds value to the set without checking that it is in range. This
+// saves a branch in hot loops, but value must be one that Add would
+// accept: passing any other value may panic or corrupt the set.
+func (g *GrowSet) AddUnchecked(value int) {
+	index := g.sparse[value-g.offset]
+	if index >= 0 && index < g.n && g.dense[index] == value {
+		return
+	}
+
+	(*set)(g).own()
+	g.extend(value)
+	g.dense[g.n] = value
+	g.sparse[value-g.offset] = g.n
+	g.n++
+}
+
+// Adds a previously removed value back to the set, like Add, but panics
+// if value is too small or too large to have been in the set. This
+// suits callers that have already validated their values and would
+// otherwise discard the error.
+func (s *ShrinkSet) MustAdd(value int) {
+	if err := s.Add(value); err != nil {
+		panic(err)
+	}
+}
+
+// Adds a previously removed value back to the set without checking that
+// it is in range. This saves a branch in hot loops, but value must be
+// one that Add would accept: passing any other value may panic or
+// corrupt the set.
+func (s *ShrinkSet) AddUnchecked(value int) {
+	valueIndex := s.indexOf(value)
+	if valueIndex < s.n {
+		return
+	}
+
+	s.extend(value)
+	firstRemoved := s.at(s.n)
+	s.put(s.n, value)
+	s.put(valueIndex, firstRemoved)
+	s.n++
+}
+
+// Adds value to the set, like Add, but panics if value is too small or
+// too large to be stored in the set. This suits callers that have
+// already validated their values and would otherwise discard the error.
+func (s *SparseSet) MustAdd(value int) {
+	if err := s.Add(value); err != nil {
+		panic(err)
+	}
+}
+
+// Adds value to the set without checking that it is in range. This
+// saves a branch in hot loops, but value must be one that Add would
+// accept: passing any other value may panic or corrupt the set.
+func (s *SparseSet) AddUnchecked(value int) {
+	index := s.sparse[value-s.offset]
+	if uint(index) < uint(s.n) && s.dense[index] == value {
+		return
+	}
+
+	(*set)(s).own()
+	s.dense[s.n] = value
+	s.sparse[value-s.offset] = s.n
+	s.n++
+}
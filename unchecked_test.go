@@ -0,0 +1,61 @@
+package intset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMustAdd(t *testing.T) {
+	g := NewGrowSet(5)
+	g.MustAdd(4)
+	assert(t, g.Contains(4), "set should contain 4")
+
+	s := NewShrinkSet(5)
+	s.Remove(2)
+	s.MustAdd(2)
+	assert(t, s.Contains(2), "set should contain 2")
+
+	p := NewSparseSet(5)
+	p.MustAdd(0)
+	assert(t, p.Contains(0), "set should contain 0")
+
+	defer func() {
+		assert(t, errors.Is(recover().(error), ErrValueOutOfRange), "MustAdd should panic with ErrValueOutOfRange")
+	}()
+
+	p.MustAdd(5)
+}
+
+func TestAddUnchecked(t *testing.T) {
+	g := NewGrowSetRange(-3, 3)
+	for _, v := range []int{-3, 2, 2, 0} {
+		g.AddUnchecked(v)
+	}
+	assertMembers(t, g.Contains, g.Size(), 3, -3, 0, 2)
+
+	max, _ := g.Max()
+	assert(t, max == 2, "max should be 2")
+
+	s := NewShrinkSet(5)
+	s.Remove(1)
+	s.Remove(3)
+	s.AddUnchecked(3)
+	s.AddUnchecked(4)
+	assertMembers(t, s.Contains, s.Size(), 5, 0, 2, 3, 4)
+
+	p := NewSparseSet(5)
+	p.AddUnchecked(1)
+	p.AddUnchecked(1)
+	assertMembers(t, p.Contains, p.Size(), 5, 1)
+}
+
+func TestAddUncheckedGarbageBuffers(t *testing.T) {
+	// Uncleared buffers may hold any value, including negative indices.
+	sparse := []int{-7, 1 << 40, -1, 3}
+	dense := []int{9, -2, 5, 0}
+	p := NewSparseSetWithBuffers(sparse, dense)
+	for _, v := range []int{0, 1, 2, 2} {
+		p.AddUnchecked(v)
+	}
+	assertMembers(t, p.Contains, p.Size(), 4, 0, 1, 2)
+}
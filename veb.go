@@ -0,0 +1,411 @@
+package intset
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// A VEBSet is a van Emde Boas tree over the integers less than its
+// capacity. It keeps its members ordered, so that the smallest and
+// largest members are found in O(1) time, and the nearest member above
+// or below any integer in O(log log u) time, where u is the capacity
+// rounded up to a power of two; this suits schedulers and allocators
+// that need ordered nearest-neighbor queries over a fixed universe.
+// Add and Remove also take O(log log u) time, and Contains takes O(1)
+// time using a bitmap kept alongside the tree. The tree takes O(u/64)
+// time and memory to construct, and does not allocate afterwards.
+type VEBSet struct {
+	n        int
+	capacity int
+	members  []uint64
+	root     vebNode
+}
+
+// A node of a van Emde Boas tree over the integers less than 1 << bits.
+// A node over at most 64 integers stores them as the bits of word.
+// Otherwise, min and max are its smallest and largest members, or -1 if
+// it is empty, and every member but min is stored in the cluster given
+// by its upper bits, as its lower bits; summary holds the indexes of the
+// nonempty clusters.
+type vebNode struct {
+	bits     int
+	word     uint64
+	min      int
+	max      int
+	summary  *vebNode
+	clusters []vebNode
+}
+
+// The largest number of bits a leaf of the tree covers.
+const vebLeafBits = 6
+
+// Allocate a new, empty VEBSet.
+// The resulting set will be able to store the integers less than
+// capacity.
+func NewVEBSet(capacity int) *VEBSet {
+	validateCapacity(capacity)
+	result := &VEBSet{
+		capacity: capacity,
+		members:  make([]uint64, (capacity+63)/64),
+	}
+
+	result.root.init(max(bits.Len(uint(max(capacity, 1)-1)), 1))
+	return result
+}
+
+// Sets up n as an empty node over the integers less than 1 << b.
+func (n *vebNode) init(b int) {
+	n.bits, n.min, n.max = b, -1, -1
+	if b <= vebLeafBits {
+		return
+	}
+
+	n.summary = &vebNode{}
+	n.summary.init(n.highBits())
+	n.clusters = make([]vebNode, 1<<n.highBits())
+	for i := range n.clusters {
+		n.clusters[i].init(n.lowBits())
+	}
+}
+
+// Returns the number of lower bits of a member stored in its cluster.
+func (n *vebNode) lowBits() int {
+	return n.bits / 2
+}
+
+// Returns the number of upper bits of a member, which give its cluster.
+func (n *vebNode) highBits() int {
+	return n.bits - n.lowBits()
+}
+
+// Splits x into the index of its cluster and its position within it.
+func (n *vebNode) split(x int) (int, int) {
+	return x >> n.lowBits(), x & (1<<n.lowBits() - 1)
+}
+
+// Returns the integer at position low of cluster high.
+func (n *vebNode) join(high, low int) int {
+	return high<<n.lowBits() | low
+}
+
+// Returns true if the node has no members.
+func (n *vebNode) empty() bool {
+	if n.bits <= vebLeafBits {
+		return n.word == 0
+	}
+
+	return n.min < 0
+}
+
+// Returns the smallest member of the node, or -1 if it is empty.
+func (n *vebNode) minimum() int {
+	if n.bits <= vebLeafBits {
+		if n.word == 0 {
+			return -1
+		}
+
+		return bits.TrailingZeros64(n.word)
+	}
+
+	return n.min
+}
+
+// Returns the largest member of the node, or -1 if it is empty.
+func (n *vebNode) maximum() int {
+	if n.bits <= vebLeafBits {
+		if n.word == 0 {
+			return -1
+		}
+
+		return 63 - bits.LeadingZeros64(n.word)
+	}
+
+	return n.max
+}
+
+// Adds x, which must not be a member, to the node.
+func (n *vebNode) insert(x int) {
+	if n.bits <= vebLeafBits {
+		n.word |= 1 << x
+		return
+	}
+
+	if n.min < 0 {
+		n.min, n.max = x, x
+		return
+	}
+
+	if x < n.min {
+		x, n.min = n.min, x
+	}
+
+	high, low := n.split(x)
+	if n.clusters[high].empty() {
+		n.summary.insert(high)
+	}
+
+	n.clusters[high].insert(low)
+	n.max = max(n.max, x)
+}
+
+// Removes x, which must be a member, from the node.
+func (n *vebNode) delete(x int) {
+	if n.bits <= vebLeafBits {
+		n.word &^= 1 << x
+		return
+	}
+
+	if n.min == n.max {
+		n.min, n.max = -1, -1
+		return
+	}
+
+	if x == n.min {
+		// Promote the smallest member stored in a cluster to be the new
+		// minimum, removing it from its cluster instead.
+		first := n.summary.minimum()
+		x = n.join(first, n.clusters[first].minimum())
+		n.min = x
+	}
+
+	high, low := n.split(x)
+	n.clusters[high].delete(low)
+	if n.clusters[high].empty() {
+		n.summary.delete(high)
+		if x == n.max {
+			if last := n.summary.maximum(); last < 0 {
+				n.max = n.min
+			} else {
+				n.max = n.join(last, n.clusters[last].maximum())
+			}
+		}
+	} else if x == n.max {
+		n.max = n.join(high, n.clusters[high].maximum())
+	}
+}
+
+// Returns the smallest member of the node greater than x, or -1 if
+// there is none.
+func (n *vebNode) successor(x int) int {
+	if n.bits <= vebLeafBits {
+		word := n.word &^ (2<<x - 1)
+		if word == 0 {
+			return -1
+		}
+
+		return bits.TrailingZeros64(word)
+	}
+
+	if n.min >= 0 && x < n.min {
+		return n.min
+	}
+
+	high, low := n.split(x)
+	if last := n.clusters[high].maximum(); last >= 0 && low < last {
+		return n.join(high, n.clusters[high].successor(low))
+	}
+
+	next := n.summary.successor(high)
+	if next < 0 {
+		return -1
+	}
+
+	return n.join(next, n.clusters[next].minimum())
+}
+
+// Returns the largest member of the node less than x, or -1 if there is
+// none.
+func (n *vebNode) predecessor(x int) int {
+	if n.bits <= vebLeafBits {
+		word := n.word & (1<<x - 1)
+		if word == 0 {
+			return -1
+		}
+
+		return 63 - bits.LeadingZeros64(word)
+	}
+
+	if n.max >= 0 && x > n.max {
+		return n.max
+	}
+
+	high, low := n.split(x)
+	if first := n.clusters[high].minimum(); first >= 0 && low > first {
+		return n.join(high, n.clusters[high].predecessor(low))
+	}
+
+	previous := n.summary.predecessor(high)
+	if previous < 0 {
+		if n.min >= 0 && x > n.min {
+			return n.min
+		}
+
+		return -1
+	}
+
+	return n.join(previous, n.clusters[previous].maximum())
+}
+
+// Empties the node and every node below it.
+func (n *vebNode) clear() {
+	n.word, n.min, n.max = 0, -1, -1
+	if n.summary == nil {
+		return
+	}
+
+	n.summary.clear()
+	for i := range n.clusters {
+		n.clusters[i].clear()
+	}
+}
+
+// Returns true if value is a member of the set.
+func (v *VEBSet) Contains(value int) bool {
+	if value < 0 || value >= v.capacity {
+		return false
+	}
+
+	return v.members[value/64]&(1<<(value%64)) != 0
+}
+
+// Removes all elements from the set.
+// This takes O(u/64) time.
+func (v *VEBSet) Clear() {
+	clear(v.members)
+	v.root.clear()
+	v.n = 0
+}
+
+// Returns the size of the set.
+func (v *VEBSet) Size() int {
+	return v.n
+}
+
+// Returns the number of distinct values the set is able to store.
+func (v *VEBSet) Capacity() int {
+	return v.capacity
+}
+
+// Adds value to the set. Adding the same value multiple times is not an error.
+// This takes O(log log u) time.
+// If a value is less than zero or too large to be stored in the set, ErrValueOutOfRange
+// is returned, otherwise nil.
+func (v *VEBSet) Add(value int) error {
+	if value < 0 || value >= v.capacity {
+		return outOfRange(value, 0, v.capacity)
+	}
+
+	if !v.Contains(value) {
+		v.members[value/64] |= 1 << (value % 64)
+		v.root.insert(value)
+		v.n++
+	}
+
+	return nil
+}
+
+// Remove the item from the set. It is not an error to
+// remove an item that does not exist.
+// This takes O(log log u) time.
+func (v *VEBSet) Remove(item int) {
+	if v.Contains(item) {
+		v.members[item/64] &^= 1 << (item % 64)
+		v.root.delete(item)
+		v.n--
+	}
+}
+
+// Returns the smallest member of the set, in O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (v *VEBSet) Min() (int, error) {
+	if v.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return v.root.minimum(), nil
+}
+
+// Returns the largest member of the set, in O(1) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (v *VEBSet) Max() (int, error) {
+	if v.n == 0 {
+		return 0, ErrEmptySet
+	}
+
+	return v.root.maximum(), nil
+}
+
+// Remove and return the smallest member of the set.
+// This takes O(log log u) time.
+// If the set is empty, the result will be 0 and error will be ErrEmptySet.
+func (v *VEBSet) Pop() (int, error) {
+	value, err := v.Min()
+	if err == nil {
+		v.Remove(value)
+	}
+
+	return value, err
+}
+
+// Returns the smallest member of the set greater than value, and true,
+// or 0 and false if there is none. The next member at or after x is
+// NextAfter(x - 1).
+// This takes O(log log u) time.
+func (v *VEBSet) NextAfter(value int) (int, bool) {
+	if v.n == 0 || value >= v.capacity-1 {
+		return 0, false
+	}
+
+	if value < 0 {
+		return v.root.minimum(), true
+	}
+
+	if next := v.root.successor(value); next >= 0 {
+		return next, true
+	}
+
+	return 0, false
+}
+
+// Returns the largest member of the set less than value, and true, or 0
+// and false if there is none.
+// This takes O(log log u) time.
+func (v *VEBSet) PrevBefore(value int) (int, bool) {
+	if v.n == 0 || value <= 0 {
+		return 0, false
+	}
+
+	if value >= v.capacity {
+		return v.root.maximum(), true
+	}
+
+	if previous := v.root.predecessor(value); previous >= 0 {
+		return previous, true
+	}
+
+	return 0, false
+}
+
+// Returns a newly allocated slice containing the members of the set,
+// in increasing order.
+func (v *VEBSet) Values() []int {
+	result := make([]int, 0, v.n)
+	for value := range v.All() {
+		result = append(result, value)
+	}
+
+	return result
+}
+
+// Returns an iterator over the members of the set, in increasing
+// order, taking O(log log u) time per member. The member being visited
+// may safely be removed during iteration.
+func (v *VEBSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for value, ok := v.NextAfter(-1); ok; value, ok = v.NextAfter(value) {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package intset
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestVEBSet(t *testing.T) {
+	s := NewVEBSet(1000)
+	_, err := s.Min()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, ok := s.NextAfter(-1)
+	assert(t, !ok, "empty set should have no successor")
+
+	for _, v := range []int{500, 3, 999, 64, 0} {
+		assert(t, s.Add(v) == nil, "error adding %v", v)
+	}
+
+	s.Add(64)
+	assert(t, s.Size() == 5, "size should be 5, is %v", s.Size())
+	assert(t, slices.Equal(s.Values(), []int{0, 3, 64, 500, 999}), "values are %v", s.Values())
+
+	min, _ := s.Min()
+	max, _ := s.Max()
+	assert(t, min == 0 && max == 999, "min and max should be 0 and 999, are %v and %v", min, max)
+
+	next, ok := s.NextAfter(64)
+	assert(t, ok && next == 500, "next after 64 should be 500, is %v", next)
+	prev, ok := s.PrevBefore(64)
+	assert(t, ok && prev == 3, "previous before 64 should be 3, is %v", prev)
+	_, ok = s.NextAfter(999)
+	assert(t, !ok, "999 should have no successor")
+	_, ok = s.PrevBefore(0)
+	assert(t, !ok, "0 should have no predecessor")
+
+	err = s.Add(1000)
+	assert(t, errors.Is(err, ErrValueOutOfRange), "error should be ErrValueOutOfRange")
+
+	value, err := s.Pop()
+	assert(t, err == nil && value == 0, "pop should return 0, returned %v", value)
+	s.Remove(999)
+	max, _ = s.Max()
+	assert(t, max == 500, "max should be 500, is %v", max)
+
+	s.Clear()
+	assertMembers(t, s.Contains, s.Size(), s.Capacity())
+}
+
+func TestVEBSetZeroCapacity(t *testing.T) {
+	s := NewVEBSet(0)
+	assert(t, s.Size() == 0 && s.Capacity() == 0, "set should be empty with no capacity")
+	assert(t, !s.Contains(0), "set should not contain 0")
+	assert(t, errors.Is(s.Add(0), ErrValueOutOfRange), "adding 0 should fail")
+	_, err := s.Pop()
+	assert(t, err == ErrEmptySet, "error should be ErrEmptySet")
+	_, ok := s.NextAfter(-1)
+	assert(t, !ok, "empty set should have no successor")
+}
+
+func TestVEBSetRandom(t *testing.T) {
+	for _, capacity := range []int{1, 2, 63, 64, 65, 1000, 5000} {
+		s := NewVEBSet(capacity)
+		reference := make(map[int]bool)
+		for i := 0; i < 4*capacity; i++ {
+			v := rand.Intn(capacity)
+			if rand.Intn(3) == 0 {
+				s.Remove(v)
+				delete(reference, v)
+			} else {
+				s.Add(v)
+				reference[v] = true
+			}
+
+			q := rand.Intn(capacity+2) - 1
+			wantNext, wantPrev, foundNext, foundPrev := 0, 0, false, false
+			for m := range reference {
+				if m > q && (!foundNext || m < wantNext) {
+					wantNext, foundNext = m, true
+				}
+
+				if m < q && (!foundPrev || m > wantPrev) {
+					wantPrev, foundPrev = m, true
+				}
+			}
+
+			next, ok := s.NextAfter(q)
+			assert(t, ok == foundNext && next == wantNext, "next after %v should be %v, is %v", q, wantNext, next)
+			prev, ok := s.PrevBefore(q)
+			assert(t, ok == foundPrev && prev == wantPrev, "previous before %v should be %v, is %v", q, wantPrev, prev)
+		}
+
+		values := s.Values()
+		assert(t, len(values) == len(reference) && s.Size() == len(reference), "size should be %v, is %v", len(reference), s.Size())
+		assert(t, slices.IsSorted(values), "values should be sorted: %v", values)
+		for _, v := range values {
+			assert(t, reference[v], "%v should not be a member", v)
+		}
+	}
+}